@@ -3,11 +3,45 @@
 package abi
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"unicode/utf8"
 )
 
+// ErrEmptyInput is returned by the decoders of this package when given a
+// zero-length input where at least one value is expected.  Decoding an
+// empty input succeeds where the empty encoding is valid, for example,
+// Decode with an empty schema.
+var ErrEmptyInput = errors.New("empty input")
+
+// DecodeError is returned when an element of a tuple fails to decode.  It
+// identifies the element, so that callers can extract it with errors.As
+// rather than by parsing the message.
+type DecodeError struct {
+	// ElementIndex is the index of the element within its tuple.
+	ElementIndex int
+	// Offset is the position of the head slot of the element within the
+	// encoding of its tuple.
+	Offset uint64
+	// Cause is the error that occurred while decoding the element.
+	Cause error
+}
+
+// Error returns the message of the error.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding element %d: %v", e.ElementIndex, e.Cause)
+}
+
+// Unwrap returns the cause of the error.
+func (e *DecodeError) Unwrap() error {
+	return e.Cause
+}
+
 func isNonZero(b []byte) bool {
 	for i := range b {
 		if b[i] != 0 {
@@ -17,6 +51,33 @@ func isNonZero(b []byte) bool {
 	return false
 }
 
+// firstNonZero returns the index of the first non-zero byte of b, or -1
+// if all bytes are zero.  It is a slower alternative to isNonZero for
+// reporting where invalid data starts.
+func firstNonZero(b []byte) int {
+	for i := range b {
+		if b[i] != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkPadding checks that all bytes of padding are zero, reporting the
+// first non-zero byte, and its index within padding, otherwise.
+func checkPadding(padding []byte) error {
+	i := firstNonZero(padding)
+	if i < 0 {
+		return nil
+	}
+	return paddingError(padding, i)
+}
+
+func paddingError(padding []byte, i int) error {
+	format := "padding contains non-zero values at index %d (0x%02x)"
+	return fmt.Errorf(format, i, padding[i])
+}
+
 // sliceEqual checks equality of two byte slices.
 func sliceEqual(a, b []byte) bool {
 	if len(a) != len(b) {
@@ -34,25 +95,63 @@ func sliceEqual(a, b []byte) bool {
 // operation of DecodeUint64.
 func EncodeUint64(v uint64) []byte {
 	out := make([]byte, 32)
-	binary.BigEndian.PutUint64(out[24:], v)
+
+	// out is always a word, so encoding into it cannot fail
+	_ = EncodeUint64Into(out, v)
 	return out
 }
 
+// EncodeUint64Into encodes a uint64 to 32-byte ABI format like
+// EncodeUint64, but writes the encoding to dst rather than allocating it.
+// This allows reusing a buffer when encoding many values.  It errors if dst
+// is not exactly 32 bytes long.
+func EncodeUint64Into(dst []byte, v uint64) error {
+	if len(dst) != 32 {
+		return fmt.Errorf("destination of %d bytes must contain 32 bytes", len(dst))
+	}
+
+	clear(dst[:24])
+	binary.BigEndian.PutUint64(dst[24:], v)
+	return nil
+}
+
 // DecodeUint64 decodes ABI bytes back to uint64. It is the inverse operation
 // of EncodeUint64.
 func DecodeUint64(v []byte) (uint64, error) {
-	if len(v) != 32 {
+	switch {
+	case len(v) == 0:
+		return 0, ErrEmptyInput
+	case len(v) != 32:
 		return 0, errors.New("uint64 encoding must contain 32 bytes")
 	}
 
+	// DecodeUint64 is on the hot path for every offset and count, so the
+	// padding is checked as three words rather than byte by byte
 	padding, data := v[:24], v[24:]
-	if isNonZero(padding) {
-		return 0, fmt.Errorf("padding contains non-zero values")
+	word0 := binary.BigEndian.Uint64(padding[0:8])
+	word1 := binary.BigEndian.Uint64(padding[8:16])
+	word2 := binary.BigEndian.Uint64(padding[16:24])
+	if word0|word1|word2 != 0 {
+		return 0, paddingError(padding, firstNonZero(padding))
 	}
 
 	return binary.BigEndian.Uint64(data), nil
 }
 
+// DecodeUint64Bounded decodes a uint64 like DecodeUint64, and then checks
+// that it does not exceed max.  It is intended for fields of untrusted
+// input, such as lengths and indices, that have a known upper bound.
+func DecodeUint64Bounded(v []byte, max uint64) (uint64, error) {
+	n, err := DecodeUint64(v)
+	if err != nil {
+		return 0, err
+	}
+	if n > max {
+		return 0, fmt.Errorf("value %d exceeds maximum %d", n, max)
+	}
+	return n, nil
+}
+
 func padRight(data []byte, length int) ([]byte, error) {
 	if length < len(data) {
 		format := "length %d smaller than input %d"
@@ -64,6 +163,29 @@ func padRight(data []byte, length int) ([]byte, error) {
 	return padded, nil
 }
 
+func padLeft(data []byte, length int) ([]byte, error) {
+	if length < len(data) {
+		format := "length %d smaller than input %d"
+		return nil, fmt.Errorf(format, length, len(data))
+	}
+
+	padded := make([]byte, length)
+	copy(padded[length-len(data):], data)
+	return padded, nil
+}
+
+// zeroWord is a shared 32-byte word of zeros used for padding.  It must
+// never be modified, and exported functions copy from it rather than
+// return slices that alias it.
+var zeroWord [32]byte
+
+// EmptyBytes returns the encoding of empty bytes, that is, a zero length
+// word.
+func EmptyBytes() []byte {
+	out := zeroWord
+	return out[:]
+}
+
 // precomputed 32-byte slice header where last byte is 0x20
 var precomputedSliceHeader = func() []byte {
 	s := make([]byte, 32)
@@ -78,28 +200,187 @@ func SliceHeader() []byte {
 	return out
 }
 
-func nextMultipleOf32(n int) int {
+// nextMultipleOf32 rounds n up to a multiple of 32.  It returns an error
+// rather than wrapping around when the result does not fit in an int.
+func nextMultipleOf32(n int) (int, error) {
+	if n > math.MaxInt-31 {
+		return 0, fmt.Errorf("length %d too large to pad to a multiple of 32", n)
+	}
 	remainder := n % 32
-	return n + (32-remainder)%32
+	return n + (32-remainder)%32, nil
 }
 
 // EncodeBytes encodes a byte slice (in the go sense) to a bytes type
 // (in the evm sense).  It is the inverse operation of DecodeBytes.
 func EncodeBytes(v []byte) ([]byte, error) {
-	vLen := len(v)
-	head := EncodeUint64(uint64(vLen))
-	tail, err := padRight(v, nextMultipleOf32(vLen))
+	alignedLen, err := nextMultipleOf32(len(v))
 	if err != nil {
-		return nil, fmt.Errorf("padding, %w", err)
+		return nil, err
+	}
+	return EncodeBytesWithPad(v, alignedLen)
+}
+
+// EncodeBytesWithPad is like EncodeBytes, but takes the length of v
+// rounded up to a multiple of 32 from the caller, which saves recomputing
+// it when encoding many values of the same length.  It returns an error
+// if alignedLen is not that length.
+func EncodeBytesWithPad(v []byte, alignedLen int) ([]byte, error) {
+	vLen := len(v)
+	switch {
+	case alignedLen%32 != 0:
+		return nil, fmt.Errorf("aligned length %d not 32-byte aligned", alignedLen)
+	case alignedLen < vLen || alignedLen >= vLen+32:
+		format := "aligned length %d inconsistent with input %d"
+		return nil, fmt.Errorf(format, alignedLen, vLen)
 	}
 
-	return append(head, tail...), nil
+	// write the head (length) and tail (data and padding) into a single
+	// allocation, taking the zeros of both from zeroWord
+	out := make([]byte, 0, 32+alignedLen)
+	out = append(out, zeroWord[:24]...)
+	out = binary.BigEndian.AppendUint64(out, uint64(vLen))
+	out = append(out, v...)
+	out = append(out, zeroWord[:alignedLen-vLen]...)
+	return out, nil
 }
 
 // DecodeBytes decodes a byte slice (in the go sense) from an
 // abi encoding of Bytes (in the evm sense).  It is the inverse operation
 // of EncodeBytes.
 func DecodeBytes(abiEncoded []byte) ([]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return decodeBytes(abiEncoded, &DecodeOptions{})
+}
+
+// DecodeBytesWithOptions decodes a byte slice like DecodeBytes, subject to
+// the limits of opts.  A length over MaxBytes or MaxTotalBytes is rejected
+// before the data is copied.  It is intended for attacker-controlled
+// input.
+func DecodeBytesWithOptions(abiEncoded []byte, opts DecodeOptions) ([]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return decodeBytes(abiEncoded, &opts)
+}
+
+// ValidateBytesEncoding checks that abiEncoded is a well-formed bytes,
+// running the same checks as DecodeBytes without copying out the data.
+func ValidateBytesEncoding(abiEncoded []byte) error {
+	if len(abiEncoded) == 0 {
+		return ErrEmptyInput
+	}
+	_, err := bytesData(abiEncoded, &DecodeOptions{}, DecodeUint64)
+	return err
+}
+
+// DecodeBytesInto decodes a byte slice like DecodeBytes, but copies the
+// data into dst rather than allocating it.  This allows reusing a buffer
+// when decoding many values.  It returns the number of bytes written and
+// errors if dst is too small to hold the data.
+func DecodeBytesInto(abiEncoded, dst []byte) (int, error) {
+	if len(abiEncoded) == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	data, err := bytesData(abiEncoded, &DecodeOptions{}, DecodeUint64)
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < len(data) {
+		format := "destination of %d bytes too small for %d bytes of data"
+		return 0, fmt.Errorf(format, len(dst), len(data))
+	}
+	return copy(dst, data), nil
+}
+
+// DecodeBytesNoCopy decodes a byte slice like DecodeBytes, but returns the
+// region of abiEncoded that holds the data rather than a copy of it.  The
+// result aliases abiEncoded, so it must not be modified and is only valid
+// until abiEncoded is reused.  Its capacity is limited to its length, so
+// appending to it does not overwrite abiEncoded.
+func DecodeBytesNoCopy(abiEncoded []byte) ([]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	data, err := bytesData(abiEncoded, &DecodeOptions{}, DecodeUint64)
+	if err != nil {
+		return nil, err
+	}
+	return data[:len(data):len(data)], nil
+}
+
+// DecodeBytesN decodes a byte slice like DecodeBytes from the start of
+// abiEncoded, which may be followed by further data.  It also returns the
+// number of bytes that the encoding occupied, that is, 32 for the length
+// plus the padded data, so that the caller can advance to the next value
+// of a sequence of concatenated encodings.
+func DecodeBytesN(abiEncoded []byte) (value []byte, consumed int, err error) {
+	switch {
+	case len(abiEncoded) == 0:
+		return nil, 0, ErrEmptyInput
+	case len(abiEncoded) < 32:
+		return nil, 0, errors.New("not long enough to have a head")
+	}
+
+	dataLen, err := DecodeUint64(abiEncoded[:32])
+	if err != nil {
+		return nil, 0, fmt.Errorf("decoding data length, %w", err)
+	}
+	if dataLen > uint64(len(abiEncoded)-32) {
+		return nil, 0, fmt.Errorf("length in head is out of range")
+	}
+
+	alignedLen, err := nextMultipleOf32(int(dataLen))
+	switch {
+	case err != nil:
+		return nil, 0, err
+	case alignedLen > len(abiEncoded)-32:
+		return nil, 0, fmt.Errorf("padding is out of range")
+	}
+	consumed = 32 + alignedLen
+
+	value, err = decodeBytes(abiEncoded[:consumed], &DecodeOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, consumed, nil
+}
+
+func decodeBytes(abiEncoded []byte, opts *DecodeOptions) ([]byte, error) {
+	return decodeBytesWithLength(abiEncoded, opts, DecodeUint64)
+}
+
+// decodeBytesWithLength decodes bytes where decodeLength reads the length
+// of the data from the head.
+func decodeBytesWithLength(
+	abiEncoded []byte,
+	opts *DecodeOptions,
+	decodeLength func([]byte) (uint64, error),
+) ([]byte, error) {
+	data, err := bytesData(abiEncoded, opts, decodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.charge(len(data)); err != nil {
+		return nil, err
+	}
+
+	dst := make([]byte, len(data))
+	copy(dst, data)
+	return dst, nil
+}
+
+// bytesData validates the encoding of bytes and returns the region of
+// abiEncoded that holds the data.
+func bytesData(
+	abiEncoded []byte,
+	opts *DecodeOptions,
+	decodeLength func([]byte) (uint64, error),
+) ([]byte, error) {
 	// We specify a few names to help understand the layout.
 	// Note that the '|' is not part of the layout, it is just a visual aid.
 	// | head (32 bytes) | tail (padded to a multiple of 32 bytes) |
@@ -127,14 +408,17 @@ func DecodeBytes(abiEncoded []byte) ([]byte, error) {
 	tail := abiEncoded[headLen:]
 
 	// unpack the head
-	dataLen, err := DecodeUint64(head)
+	dataLen, err := decodeLength(head)
 	if err != nil {
 		return nil, fmt.Errorf("decoding data length, %w", err)
 	}
 
 	// validate the content in the head
-	if dataLen > uint64(len(tail)) {
+	switch {
+	case dataLen > uint64(len(tail)):
 		return nil, fmt.Errorf("length in head is out of range")
+	case opts.MaxBytes > 0 && dataLen > uint64(opts.MaxBytes):
+		return nil, fmt.Errorf("length %d exceeds limit %d", dataLen, opts.MaxBytes)
 	}
 
 	// unpack the tail
@@ -142,36 +426,64 @@ func DecodeBytes(abiEncoded []byte) ([]byte, error) {
 	padding := tail[dataLen:]
 
 	// validate the content in the tail
-	switch {
-	case len(padding) >= 32:
+	if len(padding) >= 32 {
 		return nil, fmt.Errorf("invalid padding length '%d'", len(padding))
-	case isNonZero(padding):
-		return nil, fmt.Errorf("padding contains non-zero values")
+	}
+	if err := checkPadding(padding); err != nil {
+		return nil, err
 	}
 
-	dst := make([]byte, dataLen)
-	copy(dst, data)
-	return dst, nil
+	return data, nil
+}
+
+// LooksDoubleEncoded is a lint-style check for the common mistake of
+// calling EncodeBytes on a value that is already ABI encoded.  It reports
+// whether v is itself a valid encoding of bytes, that is, whether it starts
+// with a length word matching the rest of v, correctly padded.  It is
+// advisory only, as a raw value may happen to have that shape, for example,
+// 32 zero bytes look like an encoding of empty bytes.
+func LooksDoubleEncoded(v []byte) bool {
+	_, err := DecodeBytes(v)
+	return err == nil
 }
 
 // EncodeSliceOfBytes encodes a slice of byte arrays (in the go sense) to a
 // bytes type (in the evm sense).  It is the inverse operation of
 // DecodeSliceOfBytes.
 func EncodeSliceOfBytes(v [][]byte) ([]byte, error) {
-	k := len(v)
+	// elements often share a length, so the aligned length is only
+	// recomputed when the length changes
+	encodedElems := make([][]byte, len(v))
+	prevLen, alignedLen := 0, 0
+	for i := range v {
+		if len(v[i]) != prevLen {
+			n, err := nextMultipleOf32(len(v[i]))
+			if err != nil {
+				return nil, fmt.Errorf("encoding element %d, %w", i, err)
+			}
+			prevLen, alignedLen = len(v[i]), n
+		}
+		enc, err := EncodeBytesWithPad(v[i], alignedLen)
+		if err != nil {
+			return nil, fmt.Errorf("encoding element %d, %w", i, err)
+		}
+		encodedElems[i] = enc
+	}
+
+	return encodeSliceOfDynamic(encodedElems), nil
+}
+
+// encodeSliceOfDynamic lays out already encoded dynamic elements as a
+// slice, that is, a slice header, the element count, an offset for each
+// element and then the elements themselves.
+func encodeSliceOfDynamic(encodedElems [][]byte) []byte {
+	k := len(encodedElems)
 
 	// head size = 32 (slice header) + 32 (length) + 32*k (offsets)
 	headSize := 64 + 32*k
-	// compute tail size by encoding each element (encoded = 32 + paddedData)
 	tailSize := 0
-	encodedElems := make([][]byte, k)
 	for i := range k {
-		enc, err := EncodeBytes(v[i])
-		if err != nil {
-			return nil, fmt.Errorf("encoding element %d, %w", i, err)
-		}
-		encodedElems[i] = enc
-		tailSize += len(enc)
+		tailSize += len(encodedElems[i])
 	}
 
 	// allocate final buffer in one shot
@@ -193,13 +505,281 @@ func EncodeSliceOfBytes(v [][]byte) ([]byte, error) {
 		out = append(out, encodedElems[i]...)
 	}
 
-	return out, nil
+	return out
 }
 
 // DecodeSliceOfBytes decodes a slice of byte arrays (in the go sense) from an
 // abi encoding of Bytes (in the evm sense).  It is the inverse operation
-// of EncodeSliceOfBytes.
+// of EncodeSliceOfBytes.  The encoding does not distinguish nil from empty
+// elements, so empty elements are always decoded as non-nil empty slices.
 func DecodeSliceOfBytes(abiEncoded []byte) ([][]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return decodeSliceOfBytes(abiEncoded, &DecodeOptions{})
+}
+
+// DecodeSliceOfBytesWithOptions decodes a slice of byte arrays like
+// DecodeSliceOfBytes, subject to the limits of opts.  MaxElements caps
+// the element count, which is checked before the elements are allocated,
+// while MaxBytes caps each element and MaxTotalBytes all of them
+// together.  It is intended for attacker-controlled input.
+func DecodeSliceOfBytesWithOptions(abiEncoded []byte, opts DecodeOptions) ([][]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return decodeSliceOfBytes(abiEncoded, &opts)
+}
+
+// RangeSliceOfBytes decodes a bytes[] like DecodeSliceOfBytes, but calls
+// yield with each element in turn rather than collecting them, and stops
+// early if yield returns false.  The elements alias abiEncoded, so a large
+// slice is processed with constant extra memory.  The encoding is checked
+// as by DecodeSliceOfBytes, but an element is only checked when it is
+// reached, so yield may see some elements before an error is returned.
+func RangeSliceOfBytes(abiEncoded []byte, yield func(i int, elem []byte) bool) error {
+	if len(abiEncoded) == 0 {
+		return ErrEmptyInput
+	}
+
+	opts := &DecodeOptions{}
+	tail, k, err := sliceOfDynamicTail(abiEncoded, opts)
+	if err != nil || k == 0 {
+		return err
+	}
+
+	start, err := sliceOffset(tail, k, 0)
+	if err != nil {
+		return err
+	}
+	for i := range k {
+		end := len(tail)
+		if i+1 < k {
+			end, err = sliceOffset(tail, k, i+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		elem, err := sliceElement(tail, start, end)
+		if err != nil {
+			return err
+		}
+		data, err := bytesData(elem, opts, DecodeUint64)
+		if err != nil {
+			return fmt.Errorf("decoding element %d, %w", i, err)
+		}
+		if !yield(i, data) {
+			return nil
+		}
+		start = end
+	}
+	return nil
+}
+
+// ValidateSliceOfBytesEncoding checks that abiEncoded is a well-formed
+// bytes[], running the same checks as DecodeSliceOfBytes without copying
+// out the elements.
+func ValidateSliceOfBytesEncoding(abiEncoded []byte) error {
+	if len(abiEncoded) == 0 {
+		return ErrEmptyInput
+	}
+	_, err := sliceOfBytesData(abiEncoded, &DecodeOptions{})
+	return err
+}
+
+// DecodeSliceOfBytesStrict decodes a slice of byte arrays like
+// DecodeSliceOfBytes, but also rejects encodings that are parseable yet
+// not canonical, that is, not exactly as EncodeSliceOfBytes produces them.
+// The first offset must point just past the offset table, each following
+// offset just past the previous element, and the last element must end
+// the input, so that there are no gaps, reordered elements or trailing
+// bytes.  It is intended for consensus-sensitive code
+// that must reject any non-standard encoding.
+func DecodeSliceOfBytesStrict(abiEncoded []byte) ([][]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	opts := &DecodeOptions{}
+	elems, err := sliceOfBytesData(abiEncoded, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the offsets were validated along with the elements, so they can be
+	// read directly and compared to those of the canonical encoding
+	tail := abiEncoded[64:]
+	want := 32 * len(elems)
+	for i := range elems {
+		got := binary.BigEndian.Uint64(tail[32*i+24 : 32*i+32])
+		if got != uint64(want) {
+			return nil, fmt.Errorf("offset at index %d is %d, canonically %d", i, got, want)
+		}
+
+		// the element is within the input, so its padded length fits
+		alignedLen, _ := nextMultipleOf32(len(elems[i]))
+		want += 32 + alignedLen
+	}
+	if want != len(tail) {
+		return nil, fmt.Errorf("%d trailing bytes after the last element", len(tail)-want)
+	}
+
+	return copySliceOfBytes(elems, opts)
+}
+
+func decodeSliceOfBytes(abiEncoded []byte, opts *DecodeOptions) ([][]byte, error) {
+	elems, err := sliceOfBytesData(abiEncoded, opts)
+	if err != nil {
+		return nil, err
+	}
+	return copySliceOfBytes(elems, opts)
+}
+
+// copySliceOfBytes copies the data of the elements of a bytes[], as found
+// by sliceOfBytesData, out of the input.
+func copySliceOfBytes(elems [][]byte, opts *DecodeOptions) ([][]byte, error) {
+	results := make([][]byte, len(elems))
+	for i := range elems {
+		if err := opts.charge(len(elems[i])); err != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, err)
+		}
+		results[i] = make([]byte, len(elems[i]))
+		copy(results[i], elems[i])
+	}
+
+	return results, nil
+}
+
+// sliceOfBytesData validates the encoding of a bytes[] and returns the
+// region of abiEncoded that holds the data of each element.
+func sliceOfBytesData(abiEncoded []byte, opts *DecodeOptions) ([][]byte, error) {
+	elems, err := splitSliceOfDynamic(abiEncoded, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range elems {
+		data, err := bytesData(elems[i], opts, DecodeUint64)
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, err)
+		}
+		elems[i] = data
+	}
+	return elems, nil
+}
+
+// EncodeSliceOfStrings encodes a slice of strings to a string[].  The
+// layout is the same as that of a bytes[], so it is encoded as by
+// EncodeSliceOfBytes.  It is the inverse operation of DecodeSliceOfStrings.
+func EncodeSliceOfStrings(v []string) ([]byte, error) {
+	elems := make([][]byte, len(v))
+	for i := range v {
+		elems[i] = []byte(v[i])
+	}
+	return EncodeSliceOfBytes(elems)
+}
+
+// DecodeSliceOfStrings decodes a slice of strings from a string[],
+// checking that each element is valid UTF-8.  It is the inverse operation
+// of EncodeSliceOfStrings.
+func DecodeSliceOfStrings(abiEncoded []byte) ([]string, error) {
+	elems, err := DecodeSliceOfBytes(abiEncoded)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, len(elems))
+	for i := range elems {
+		if !utf8.Valid(elems[i]) {
+			return nil, fmt.Errorf("decoding element %d, string is not valid utf-8", i)
+		}
+		results[i] = string(elems[i])
+	}
+	return results, nil
+}
+
+// EncodeSliceOfUint64 encodes a slice of uint64 values to a uint256[].
+// The elements are static, so they are stored inline after the element
+// count, without offsets.  It is the inverse operation of
+// DecodeSliceOfUint64.
+func EncodeSliceOfUint64(v []uint64) []byte {
+	out := make([]byte, 0, 64+32*len(v))
+	out = append(out, precomputedSliceHeader...)
+	out = append(out, EncodeUint64(uint64(len(v)))...)
+	for i := range v {
+		out = append(out, EncodeUint64(v[i])...)
+	}
+	return out
+}
+
+// DecodeSliceOfUint64 decodes a slice of uint64 values from a uint256[],
+// or any other uint<N>[], whose elements all fit in a uint64.  It is the
+// inverse operation of EncodeSliceOfUint64.
+func DecodeSliceOfUint64(abiEncoded []byte) ([]uint64, error) {
+	switch {
+	case len(abiEncoded) == 0:
+		return nil, ErrEmptyInput
+	case len(abiEncoded) < 32:
+		return nil, errors.New("not long enough to have a head")
+	case !sliceEqual(abiEncoded[:32], precomputedSliceHeader):
+		return nil, errors.New("not a slice type")
+	}
+
+	words, err := splitSliceOfStatic(abiEncoded[32:], &DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]uint64, len(words))
+	for i := range words {
+		results[i], err = DecodeUint64(words[i])
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, err)
+		}
+	}
+	return results, nil
+}
+
+// splitSliceOfStatic validates the layout of the count and elements of a
+// slice of single word static elements, that is, the part of a slice
+// encoding that follows the slice header, and returns the word of each
+// element.  Static elements are stored inline, so unlike a slice of
+// dynamic elements, there are no offsets.  The returned words alias body.
+func splitSliceOfStatic(body []byte, opts *DecodeOptions) ([][]byte, error) {
+	if len(body) < 32 {
+		return nil, errors.New("not long enough to have an element count")
+	}
+
+	eltCount, err := DecodeUint64(body[:32])
+	if err != nil {
+		return nil, fmt.Errorf("decoding element count, %w", err)
+	}
+
+	elems := body[32:]
+	switch {
+	case opts.MaxElements > 0 && eltCount > uint64(opts.MaxElements):
+		format := "element count %d exceeds limit %d"
+		return nil, fmt.Errorf(format, eltCount, opts.MaxElements)
+	case eltCount > math.MaxInt/32:
+		// 32*eltCount would overflow
+		return nil, fmt.Errorf("element count %d out of range", eltCount)
+	case eltCount > uint64(len(elems)/32) || len(elems) != 32*int(eltCount):
+		format := "slice of %d elements must contain %d bytes"
+		return nil, fmt.Errorf(format, eltCount, 32*eltCount)
+	}
+
+	words := make([][]byte, eltCount)
+	for i := range words {
+		words[i] = elems[i*32 : (i+1)*32]
+	}
+	return words, nil
+}
+
+// splitSliceOfDynamic validates the layout of a slice of dynamic elements
+// and returns the encoded region of each element.  The returned regions
+// alias abiEncoded.
+func splitSliceOfDynamic(abiEncoded []byte, opts *DecodeOptions) ([][]byte, error) {
 	// We specify a few names to help understand the layout.
 	// Note that the '|' is not part of the layout, it is just a visual aid.
 	//
@@ -219,15 +799,44 @@ func DecodeSliceOfBytes(abiEncoded []byte) ([][]byte, error) {
 	// note that because the head is 64 the offsets are 32*k bytes
 	// and each element is padded to a multiple of 32 bytes,
 	// a valid input must always have a length that is a multiple of 32.
+	tail, k, err := sliceOfDynamicTail(abiEncoded, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse offsets (there are k offsets)
+	offsets := make([]int, k+1) // +1 sentinel for tail length
+	for i := range k {
+		offsets[i], err = sliceOffset(tail, k, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	offsets[k] = len(tail)
+
+	// use offsets to find the region of each encoded element
+	results := make([][]byte, k)
+	for i := range k {
+		results[i], err = sliceElement(tail, offsets[i], offsets[i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
 
+// sliceOfDynamicTail validates the head of a slice of dynamic elements, as
+// laid out for splitSliceOfDynamic, and returns its tail and element count.
+func sliceOfDynamicTail(abiEncoded []byte, opts *DecodeOptions) ([]byte, int, error) {
 	headLen := 64
 	abiEncodedLen := len(abiEncoded)
 
 	switch {
 	case abiEncodedLen < headLen:
-		return nil, errors.New("not long enough to have a head")
+		return nil, 0, errors.New("not long enough to have a head")
 	case abiEncodedLen%32 != 0:
-		return nil, fmt.Errorf("invalid length '%d' not 32-byte aligned", abiEncodedLen)
+		return nil, 0, fmt.Errorf("invalid length '%d' not 32-byte aligned", abiEncodedLen)
 	}
 
 	head := abiEncoded[:headLen]
@@ -239,58 +848,55 @@ func DecodeSliceOfBytes(abiEncoded []byte) ([][]byte, error) {
 
 	eltCount, err := DecodeUint64(eltCountBytes)
 	if err != nil {
-		return nil, fmt.Errorf("decoding element count, %w", err)
+		return nil, 0, fmt.Errorf("decoding element count, %w", err)
 	}
 
-	// validate head data
-	offsetsLen := 32 * eltCount
+	// validate head data, the element count is compared against the
+	// number of offsets that fit in the tail, as 32*eltCount may overflow
 	if !sliceEqual(typeBytes, precomputedSliceHeader) {
-		return nil, errors.New("not a slice type")
+		return nil, 0, errors.New("not a slice type")
+	}
+	if eltCount > uint64(tailLen/32) {
+		return nil, 0, fmt.Errorf("tail too short for %d elements", eltCount)
 	}
-	if offsetsLen > uint64(tailLen) {
-		return nil, fmt.Errorf("tail too short for %d elements", eltCount)
+	if opts.MaxElements > 0 && eltCount > uint64(opts.MaxElements) {
+		format := "element count %d exceeds limit %d"
+		return nil, 0, fmt.Errorf(format, eltCount, opts.MaxElements)
 	}
+	return tail, int(eltCount), nil
+}
 
-	// parse offsets (there are eltCount offsets)
-	k := int(eltCount)
-	offsets := make([]uint64, k+1) // +1 sentinel for tail length
-	for i := range k {
-		start := i * 32
-		end := start + 32
-		if end > len(tail) {
-			return nil, fmt.Errorf("decoding offset for index %d: out of range", i)
-		}
-		offset, err := DecodeUint64(tail[start:end])
-		switch {
-		case err != nil:
-			return nil, fmt.Errorf("decoding offset for index %d, %w", i, err)
-		case offset >= uint64(tailLen):
-			return nil, fmt.Errorf("offset at index %d out of bounds", i)
-		}
-		offsets[i] = offset
+// sliceOffset reads the offset of the i-th of the k elements of a slice of
+// dynamic elements from the tail of the slice.  The offset must point past
+// the table of k offsets that starts the tail, to where the elements are.
+func sliceOffset(tail []byte, k, i int) (int, error) {
+	word, err := ReadWord(tail, i)
+	if err != nil {
+		return 0, fmt.Errorf("decoding offset for index %d: %w", i, err)
+	}
+	offset, err := DecodeUint64(word)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("decoding offset for index %d, %w", i, err)
+	case offset >= uint64(len(tail)):
+		return 0, fmt.Errorf("offset at index %d out of bounds", i)
+	case offset < uint64(32*k):
+		return 0, fmt.Errorf("offset at index %d points into the offset table", i)
 	}
-	offsets[k] = uint64(tailLen)
+	return int(offset), nil
+}
 
-	// use offsets to read and decode each encoded byte array
-	results := make([][]byte, k)
-	for i := range k {
-		start := int(offsets[i])
-		end := int(offsets[i+1])
-		switch {
-		case start >= end:
-			return nil, fmt.Errorf("start %d greater than end %d", start, end)
-		case end > len(tail):
-			return nil, fmt.Errorf("end is out of bounds")
-		}
-
-		r, err := DecodeBytes(tail[start:end])
-		if err != nil {
-			return nil, fmt.Errorf("decoding element %d, %w", i, err)
-		}
-		results[i] = r
+// sliceElement returns the region of the tail of a slice of dynamic
+// elements that runs from the offset of an element to the offset of the
+// next one, or to the end of the tail for the last element.
+func sliceElement(tail []byte, start, end int) ([]byte, error) {
+	switch {
+	case start >= end:
+		return nil, fmt.Errorf("start %d greater than end %d", start, end)
+	case end > len(tail):
+		return nil, fmt.Errorf("end is out of bounds")
 	}
-
-	return results, nil
+	return tail[start:end], nil
 }
 
 // EncoderResult is the result of encoding a single element.  It is intended
@@ -302,43 +908,146 @@ type EncoderResult struct {
 	data     []byte
 }
 
+// NewEncoderResult creates the result of encoding a single element, where
+// data is the encoding of the element and dynamic tells whether the
+// element is stored in the tail of the tuple rather than inline.  It
+// allows writing an EncoderFunc for a type that this package does not
+// provide an encoder for.
+func NewEncoderResult(dynamic bool, data []byte) EncoderResult {
+	return EncoderResult{indirect: dynamic, data: data}
+}
+
+// IsDynamic reports whether the element is stored in the tail of the
+// tuple, with an offset in the head, rather than inline.
+func (r EncoderResult) IsDynamic() bool {
+	return r.indirect
+}
+
 // EncoderFunc is a function that encodes a single element.  It works in
 // concert with the TupleEncoder to encode a tuple.
 type EncoderFunc func() (EncoderResult, error)
 
+// Memoize returns an EncoderFunc that runs f once, on its first call, and
+// then returns the same result and error on every call.  It suits code
+// that calls an encoder more than once, for example, to size a buffer
+// before encoding, when f is expensive or has side effects.  The returned
+// function is safe for concurrent use, and the cached result must not be
+// modified.
+func Memoize(f EncoderFunc) EncoderFunc {
+	var once sync.Once
+	var res EncoderResult
+	var err error
+	return func() (EncoderResult, error) {
+		once.Do(func() { res, err = f() })
+		return res, err
+	}
+}
+
 // EncodeTuple encodes a tuple of elements.  While one can use the EncodeTuple
 // function directly, because of its simpler interface, it is recommended to
 // use the TupleEncoder instead.
 func EncodeTuple(encoders ...EncoderFunc) ([]byte, error) {
-	n := len(encoders)
-	// head is 32*n, initial offset for tail starts after the head
-	offset := uint64(32 * n)
+	results, err := runEncoders(encoders)
+	if err != nil {
+		return nil, err
+	}
 
-	// First pass: collect results and compute total tail size
-	results := make([]EncoderResult, n)
-	tailSize := 0
-	for i := range n {
+	return assembleTuple(results)
+}
+
+// EncodeTupleChecked encodes a tuple of elements like EncodeTuple, but
+// first checks that the result of each encoder is laid out as the
+// corresponding type requires, that is, that it is dynamic exactly when the
+// type is dynamic and that static results fill the head slots of the type.
+// This guards against a chain of encoders that silently disagrees with the
+// intended signature.
+func EncodeTupleChecked(types []Type, encoders ...EncoderFunc) ([]byte, error) {
+	if len(types) != len(encoders) {
+		format := "schema has %d elements but got %d encoders"
+		return nil, fmt.Errorf(format, len(types), len(encoders))
+	}
+
+	results, err := runEncoders(encoders)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		want, got := types[i].IsDynamic(), results[i].IsDynamic()
+		switch {
+		case want && !got:
+			return nil, fmt.Errorf("field %d: expected dynamic, got static", i)
+		case !want && got:
+			return nil, fmt.Errorf("field %d: expected static, got dynamic", i)
+		case !want && len(results[i].data) != types[i].headSize():
+			format := "field %d: expected %d bytes, got %d"
+			return nil, fmt.Errorf(format, i, types[i].headSize(), len(results[i].data))
+		}
+	}
+
+	return assembleTuple(results)
+}
+
+// EncodeWrappedTuple encodes a tuple of elements preceded by a 0x20 offset
+// word.  This matches how Solidity encodes a single dynamic struct that is
+// returned from a function, where the struct is the only element of the
+// tuple of return values and is therefore referenced by its offset.  The
+// wrapper is not needed when a function has several return values or
+// when the returned struct is static, in which case EncodeTuple should be
+// used.  It is the inverse operation of DecodeWrappedTuple.
+func EncodeWrappedTuple(encoders ...EncoderFunc) ([]byte, error) {
+	data, err := EncodeTuple(encoders...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(EncodeUint64(32), data...), nil
+}
+
+// runEncoders collects the result of each encoder.
+func runEncoders(encoders []EncoderFunc) ([]EncoderResult, error) {
+	results := make([]EncoderResult, len(encoders))
+	for i := range encoders {
 		res, err := encoders[i]()
 		if err != nil {
 			return nil, fmt.Errorf("encoding: %w", err)
 		}
 		results[i] = res
-		if res.indirect {
-			tailSize += len(res.data)
-		}
+	}
+	return results, nil
+}
+
+// assembleTuple lays out encoder results as a tuple.  Static results are
+// written inline in the head, while dynamic results are written to the tail
+// and referenced from the head by their offset.
+func assembleTuple(results []EncoderResult) ([]byte, error) {
+	n := len(results)
+
+	// First pass: compute head and tail sizes.
+	headSize, tailSize, err := tupleSizes(results)
+	if err != nil {
+		return nil, err
 	}
 
 	// allocate output once: head + tail
-	out := make([]byte, 0, 32*n+tailSize)
+	out := make([]byte, headSize, headSize+tailSize)
 
-	// Second pass: write head (inline values or offsets) and collect tail
+	// Second pass: write head (inline values or offsets), the initial
+	// offset for tail starts after the head
+	var word [32]byte
+	pos, offset := 0, uint64(headSize)
 	for i := range n {
 		res := results[i]
 		if !res.indirect {
-			out = append(out, res.data...)
+			pos += copy(out[pos:], res.data)
 			continue
 		}
-		out = append(out, EncodeUint64(offset)...)
+		// the offset is written in place, as the head has room for it
+		_ = EncodeUint64Into(word[:], offset)
+		if err := WriteWord(out[pos:], 0, word[:]); err != nil {
+			return nil, err
+		}
+		pos += 32
 		offset += uint64(len(res.data))
 	}
 
@@ -352,6 +1061,58 @@ func EncodeTuple(encoders ...EncoderFunc) ([]byte, error) {
 	return out, nil
 }
 
+// tupleSizes returns the sizes of the head and the tail of the tuple laid
+// out from results.  The head holds the data of static results, which may
+// span several words, and a 32-byte offset for each dynamic result.  The
+// sizes are checked as they accumulate, as a wrapped size would result in
+// offsets that point into the head.
+func tupleSizes(results []EncoderResult) (headSize, tailSize int, err error) {
+	for i := range results {
+		size := len(results[i].data)
+		headSize, tailSize, err = addTupleSize(headSize, tailSize, size, results[i].indirect)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return headSize, tailSize, nil
+}
+
+// addTupleSize adds a result of size bytes to the head and tail sizes of a
+// tuple, as laid out by tupleSizes, erroring rather than letting the total
+// wrap around.
+func addTupleSize(headSize, tailSize, size int, indirect bool) (int, int, error) {
+	if indirect {
+		if size > math.MaxInt-32-headSize-tailSize {
+			return 0, 0, errors.New("tuple tail too large to encode")
+		}
+		return headSize + 32, tailSize + size, nil
+	}
+	if size > math.MaxInt-headSize-tailSize {
+		return 0, 0, errors.New("tuple tail too large to encode")
+	}
+	return headSize + size, tailSize, nil
+}
+
+// EncodedSize returns the length of the output of EncodeTuple for
+// encoders, that is, the size of the head plus that of the tail, without
+// assembling the tuple.  It can be used to preallocate buffers or to
+// enforce a limit on the size of calldata.  Note that the encoders are
+// run to find the size of each element, so any side effects of the
+// encoders happen again when the tuple is encoded, unless the encoders
+// are wrapped with Memoize.
+func EncodedSize(encoders ...EncoderFunc) (int, error) {
+	results, err := runEncoders(encoders)
+	if err != nil {
+		return 0, err
+	}
+
+	headSize, tailSize, err := tupleSizes(results)
+	if err != nil {
+		return 0, err
+	}
+	return headSize + tailSize, nil
+}
+
 // EncodeTupleFuncUint64 encodes a uint64 as the k-th element of a tuple.
 func EncodeTupleFuncUint64(v uint64) EncoderFunc {
 	return func() (EncoderResult, error) {
@@ -372,6 +1133,60 @@ func EncodeTupleFuncBytes(v []byte) EncoderFunc {
 	}
 }
 
+// EncodeTupleFuncRawWord places a word that is already ABI encoded, such
+// as a uint256 produced by another tool, as the k-th element of a tuple.
+// The word is static and copied into the head as is, so it is only
+// checked to be 32 bytes long.
+func EncodeTupleFuncRawWord(word []byte) EncoderFunc {
+	return func() (EncoderResult, error) {
+		if len(word) != 32 {
+			err := fmt.Errorf("raw word of %d bytes must contain 32 bytes", len(word))
+			return EncoderResult{}, err
+		}
+		return EncoderResult{indirect: false, data: word}, nil
+	}
+}
+
+// EncodeTupleFuncRawBytes places bytes that are already ABI encoded, as by
+// EncodeBytes, as the k-th element of a tuple.  The encoding is checked as
+// by ValidateBytesEncoding, and is then stored in the tail as is.
+func EncodeTupleFuncRawBytes(abiEncoded []byte) EncoderFunc {
+	return func() (EncoderResult, error) {
+		if err := ValidateBytesEncoding(abiEncoded); err != nil {
+			return EncoderResult{}, fmt.Errorf("validating raw bytes: %w", err)
+		}
+		return EncoderResult{indirect: true, data: abiEncoded}, nil
+	}
+}
+
+// EncodeTupleFuncString encodes a string as the k-th element of a tuple.
+func EncodeTupleFuncString(v string) EncoderFunc {
+	return EncodeTupleFuncBytes([]byte(v))
+}
+
+// EncodeTupleFuncTuple encodes a nested tuple as the k-th element of a
+// tuple.  If any of the nested elements is dynamic the nested tuple is
+// dynamic and is stored in the tail, otherwise it is stored inline.
+func EncodeTupleFuncTuple(encoders ...EncoderFunc) EncoderFunc {
+	return func() (EncoderResult, error) {
+		results, err := runEncoders(encoders)
+		if err != nil {
+			return EncoderResult{}, fmt.Errorf("encoding tuple: %w", err)
+		}
+
+		indirect := false
+		for i := range results {
+			indirect = indirect || results[i].indirect
+		}
+
+		data, err := assembleTuple(results)
+		if err != nil {
+			return EncoderResult{}, fmt.Errorf("encoding tuple: %w", err)
+		}
+		return EncoderResult{indirect: indirect, data: data}, nil
+	}
+}
+
 // TupleEncoder is a helper for encoding a tuple of elements.  The struct
 // is used in building a fluent API for encoding a tuple.
 type TupleEncoder struct {
@@ -385,6 +1200,15 @@ func NewTupleEncoder() *TupleEncoder {
 	}
 }
 
+// NewTupleEncoderWithCapacity creates a new TupleEncoder with room for n
+// elements, which avoids growing the encoder as elements are added to
+// tuples with many elements.
+func NewTupleEncoderWithCapacity(n int) *TupleEncoder {
+	return &TupleEncoder{
+		encoders: make([]EncoderFunc, 0, max(n, 0)),
+	}
+}
+
 // Uint64 encodes a uint64 as the k-th element of a tuple.
 func (e *TupleEncoder) Uint64(v uint64) *TupleEncoder {
 	encoder := EncodeTupleFuncUint64(v)
@@ -392,6 +1216,27 @@ func (e *TupleEncoder) Uint64(v uint64) *TupleEncoder {
 	return e
 }
 
+// Uint8 encodes a uint8 as the k-th element of a tuple.
+func (e *TupleEncoder) Uint8(v uint8) *TupleEncoder {
+	encoder := EncodeTupleFuncUint8(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Uint16 encodes a uint16 as the k-th element of a tuple.
+func (e *TupleEncoder) Uint16(v uint16) *TupleEncoder {
+	encoder := EncodeTupleFuncUint16(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Uint32 encodes a uint32 as the k-th element of a tuple.
+func (e *TupleEncoder) Uint32(v uint32) *TupleEncoder {
+	encoder := EncodeTupleFuncUint32(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
 // Bytes encodes a byte slice as the k-th element of a tuple.
 func (e *TupleEncoder) Bytes(v []byte) *TupleEncoder {
 	encoder := EncodeTupleFuncBytes(v)
@@ -399,19 +1244,130 @@ func (e *TupleEncoder) Bytes(v []byte) *TupleEncoder {
 	return e
 }
 
+// RawWord places an already encoded word as the k-th element of a tuple.
+func (e *TupleEncoder) RawWord(word []byte) *TupleEncoder {
+	encoder := EncodeTupleFuncRawWord(word)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// RawBytes places already encoded bytes as the k-th element of a tuple.
+func (e *TupleEncoder) RawBytes(abiEncoded []byte) *TupleEncoder {
+	encoder := EncodeTupleFuncRawBytes(abiEncoded)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// String encodes a string as the k-th element of a tuple.
+func (e *TupleEncoder) String(v string) *TupleEncoder {
+	encoder := EncodeTupleFuncString(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// FixedUint64Array encodes a fixed-size array of uint64 values as the k-th
+// element of a tuple.
+func (e *TupleEncoder) FixedUint64Array(v []uint64) *TupleEncoder {
+	encoder := EncodeTupleFuncFixedUint64Array(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// FixedBytes32Array encodes a bytes32[len(v)] as the k-th element of a
+// tuple.
+func (e *TupleEncoder) FixedBytes32Array(v [][32]byte) *TupleEncoder {
+	encoder := EncodeTupleFuncFixedBytes32Array(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Address encodes an address as the k-th element of a tuple.
+func (e *TupleEncoder) Address(v [20]byte) *TupleEncoder {
+	encoder := EncodeTupleFuncAddress(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Bool encodes a bool as the k-th element of a tuple.
+func (e *TupleEncoder) Bool(v bool) *TupleEncoder {
+	encoder := EncodeTupleFuncBool(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Bytes32 encodes a bytes32 as the k-th element of a tuple.  Unlike Bytes,
+// the value is static and so it is stored inline.
+func (e *TupleEncoder) Bytes32(v [32]byte) *TupleEncoder {
+	encoder := EncodeTupleFuncBytes32(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Uint256 encodes a uint256 as the k-th element of a tuple.
+func (e *TupleEncoder) Uint256(v *big.Int) *TupleEncoder {
+	encoder := EncodeTupleFuncUint256(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Int256 encodes an int256 as the k-th element of a tuple.
+func (e *TupleEncoder) Int256(v *big.Int) *TupleEncoder {
+	encoder := EncodeTupleFuncInt256(v)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
+// Tuple encodes a nested tuple, whose elements are added by build, as the
+// k-th element of a tuple.  If any of the nested elements is dynamic the
+// nested tuple is dynamic and is stored in the tail, with an offset in the
+// head, otherwise it is stored inline.
+func (e *TupleEncoder) Tuple(build func(e *TupleEncoder)) *TupleEncoder {
+	inner := NewTupleEncoder()
+	build(inner)
+
+	encoder := EncodeTupleFuncTuple(inner.encoders...)
+	e.encoders = append(e.encoders, encoder)
+	return e
+}
+
 // Encode encodes the tuple.
 func (e *TupleEncoder) Encode() ([]byte, error) {
 	return EncodeTuple(e.encoders...)
 }
 
 // DecoderFunc is a function that decodes a single element.  It works in
-// concert with the TupleDecoder to decode a tuple.
+// concert with the TupleDecoder to decode a tuple.  The cur argument holds
+// the 32-byte head word of the element and full holds the encoding of the
+// enclosing tuple, so that offsets resolve relative to the start of that
+// tuple, even when it is nested inside another.
 type DecoderFunc func(cur, full []byte) error
 
 // DecodeTuple decodes a tuple of elements.  While one can use the DecodeTuple
 // function directly, because of its simpler interface, it is recommended to
-// use the TupleDecoder instead.
+// use the TupleDecoder instead.  Static elements that span several head
+// slots, such as fixed arrays, are decoded by one DecoderFunc per slot, so
+// there is a decoder for each of the HeadSlots of the tuple.
 func DecodeTuple(data []byte, decoders ...DecoderFunc) error {
+	return decodeTuple(context.Background(), data, decoders)
+}
+
+// DecodeTupleCtx decodes a tuple of elements like DecodeTuple, but aborts
+// with ctx.Err() when ctx is cancelled or its deadline is exceeded.  This
+// bounds the time spent decoding huge inputs.  The context is checked
+// before decoding and then every few elements.  The decoders cannot see
+// ctx, so nested tuples and slices are only checked while they are being
+// decoded if their decoders are given ctx too, as with
+// DecodeTupleFuncTupleCtx, DecodeSliceCtx and DecodeSliceOfTuplesFuncCtx,
+// otherwise they are decoded to completion.
+func DecodeTupleCtx(ctx context.Context, data []byte, decoders ...DecoderFunc) error {
+	return decodeTuple(ctx, data, decoders)
+}
+
+// ctxCheckInterval is the number of elements decoded between checks of
+// the context, which keeps the overhead of the checks low.
+const ctxCheckInterval = 64
+
+func decodeTuple(ctx context.Context, data []byte, decoders []DecoderFunc) error {
 	// We specify a few names to help understand the layout.
 	// Note that the '|' is not part of the layout, it is just a visual aid.
 	//
@@ -430,20 +1386,46 @@ func DecodeTuple(data []byte, decoders ...DecoderFunc) error {
 	switch {
 	case len(decoders) == 0:
 		return errors.New("no decoders provided")
+	case len(data) == 0:
+		return ErrEmptyInput
 	case len(data) < 32*len(decoders):
 		return errors.New("not long enough to support all decoders")
 	}
 
 	for i, decode := range decoders {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		cur := data[i*32 : (i+1)*32]
 		err := decode(cur, data)
 		if err != nil {
-			return fmt.Errorf("decoding element %d: %w", i, err)
+			return &DecodeError{ElementIndex: i, Offset: uint64(i * 32), Cause: err}
 		}
 	}
 	return nil
 }
 
+// DecodeTupleStrict decodes a tuple whose elements are added by build,
+// like DecodeToStruct, but also rejects data that extends beyond the end
+// of the tuple, that is, beyond the head and the furthest tail value of
+// its elements.  DecodeTuple ignores such trailing bytes, which hides
+// corrupted or maliciously padded inputs.  See TupleDecoder.DecodeStrict.
+func DecodeTupleStrict(data []byte, build func(d *TupleDecoder)) error {
+	d := NewTupleDecoder()
+	build(d)
+	return d.DecodeStrict(data)
+}
+
+// DecodeWrappedTuple decodes a tuple of elements preceded by an offset
+// word, as produced by EncodeWrappedTuple.  See EncodeWrappedTuple for
+// when the wrapper is present.
+func DecodeWrappedTuple(data []byte, decoders ...DecoderFunc) error {
+	return DecodeTuple(data, DecodeTupleFuncTuple(decoders...))
+}
+
 // DecodeTupleFuncUint64 decodes a uint64 as the k-th element of a tuple.
 func DecodeTupleFuncUint64(v *uint64) DecoderFunc {
 	return func(cur, full []byte) error {
@@ -460,55 +1442,123 @@ func DecodeTupleFuncUint64(v *uint64) DecoderFunc {
 // DecodeTupleFuncBytes decodes a byte slice as the k-th element of a tuple.
 func DecodeTupleFuncBytes(v *[]byte) DecoderFunc {
 	return func(cur, full []byte) error {
-		// We specify a few names to help understand the layout.
-		// Note that the '|' is not part of the layout, it is just a visual aid.
-		//
-		// Assume that we are processing the k-th element of an n-tuple
-		// and so our input of full is
-		// | head (32*n bytes) | tail (32-bytes aligned) |
-		//
-		// Restricting our view to just the head we have
-		// | elt1 | elt2 | ... | eltk | elt(k+1) | ... | eltn |
-		// where each elt is aligned to 32 bytes.
-		//
-		// We expect that cur is bytes of eltk
-		// those bytes will tell us the offset into full where
-		// we find the start of the bytes that we need to decode.
-		//
-		// Recall that bytes are encoded such that the first 32 bytes
-		// are the length of the data followed by the data itself,
-		// padded to 32 bytes.  First, we will get the byte count
-		// so that we know which slice from full to decode.
-		// And then decode using some helper functions.
+		data, err := tupleBytesData(cur, full)
+		if err != nil {
+			return err
+		}
+
+		vv := make([]byte, len(data))
+		copy(vv, data)
+		*v = vv
+		return nil
+	}
+}
 
+// tupleBytesData validates the bytes that the k-th element of a tuple
+// refers to and returns the region of full that holds their data.
+func tupleBytesData(cur, full []byte) ([]byte, error) {
+	// We specify a few names to help understand the layout.
+	// Note that the '|' is not part of the layout, it is just a visual aid.
+	//
+	// Assume that we are processing the k-th element of an n-tuple
+	// and so our input of full is
+	// | head (32*n bytes) | tail (32-bytes aligned) |
+	//
+	// Restricting our view to just the head we have
+	// | elt1 | elt2 | ... | eltk | elt(k+1) | ... | eltn |
+	// where each elt is aligned to 32 bytes.
+	//
+	// We expect that cur is bytes of eltk
+	// those bytes will tell us the offset into full where
+	// we find the start of the bytes that we need to decode.
+	//
+	// Recall that bytes are encoded such that the first 32 bytes
+	// are the length of the data followed by the data itself,
+	// padded to 32 bytes.  First, we will get the byte count
+	// so that we know which slice from full to decode.
+	// And then decode using some helper functions.
+	//
+	// The offset and the byte count are untrusted, so they are compared
+	// against the space left in full rather than added together, which
+	// could wrap around to a small, seemingly in bounds, value.
+
+	offset, err := DecodeUint64(cur)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding offset: %w", err)
+	case offset > uint64(len(full)) || uint64(len(full))-offset < 32:
+		return nil, fmt.Errorf("offset+32 out of bounds")
+	}
+
+	byteCountBytes := full[offset : offset+32]
+	byteCount, err := DecodeUint64(byteCountBytes)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding length : %w", err)
+	case byteCount > uint64(len(full))-offset-32:
+		return nil, fmt.Errorf("end is out of bounds")
+	}
+
+	alignedByteCount, err := nextMultipleOf32(int(byteCount))
+	start := int(offset)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("padding length: %w", err)
+	case alignedByteCount > len(full)-start-32:
+		return nil, fmt.Errorf("end is out of bounds")
+	}
+	end := start + 32 + alignedByteCount
+
+	data, err := bytesData(full[start:end], &DecodeOptions{}, DecodeUint64)
+	if err != nil {
+		return nil, fmt.Errorf("decoding bytes: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeTupleFuncString decodes a string as the k-th element of a tuple.
+// The string must be valid utf-8.
+func DecodeTupleFuncString(v *string) DecoderFunc {
+	return func(cur, full []byte) error {
+		var b []byte
+		if err := DecodeTupleFuncBytes(&b)(cur, full); err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return errors.New("string is not valid utf-8")
+		}
+
+		*v = string(b)
+		return nil
+	}
+}
+
+// DecodeTupleFuncTuple decodes a dynamic nested tuple as the k-th element
+// of a tuple.  A static nested tuple is stored inline, so its decoders
+// should instead be passed directly to the enclosing tuple.
+func DecodeTupleFuncTuple(decoders ...DecoderFunc) DecoderFunc {
+	return DecodeTupleFuncTupleCtx(context.Background(), decoders...)
+}
+
+// DecodeTupleFuncTupleCtx decodes a nested tuple like DecodeTupleFuncTuple,
+// but checks ctx while decoding its elements like DecodeTupleCtx does, so
+// that decoding a large nested tuple can be aborted.
+func DecodeTupleFuncTupleCtx(ctx context.Context, decoders ...DecoderFunc) DecoderFunc {
+	return func(cur, full []byte) error {
 		offset, err := DecodeUint64(cur)
 		switch {
 		case err != nil:
 			return fmt.Errorf("decoding offset: %w", err)
-		case offset+32 > uint64(len(full)):
-			return fmt.Errorf("offset+32 out of bounds")
+		case offset > uint64(len(full)):
+			return fmt.Errorf("offset out of bounds")
 		}
 
-		byteCountBytes := full[offset : offset+32]
-		byteCount, err := DecodeUint64(byteCountBytes)
+		// offsets within the nested tuple are relative to its start, so we
+		// decode it from the region that begins at its offset
+		err = decodeTuple(ctx, full[offset:], decoders)
 		if err != nil {
-			return fmt.Errorf("decoding length : %w", err)
+			return fmt.Errorf("decoding tuple: %w", err)
 		}
-
-		alignedByteCount := nextMultipleOf32(int(byteCount))
-		start := int(offset)
-		end := start + 32 + alignedByteCount
-		if end > len(full) {
-			return fmt.Errorf("end is out of bounds")
-		}
-
-		alignedBytes := full[start:end]
-		vv, err := DecodeBytes(alignedBytes)
-		if err != nil {
-			return fmt.Errorf("decoding bytes: %w", err)
-		}
-
-		*v = vv
 		return nil
 	}
 }
@@ -517,6 +1567,20 @@ func DecodeTupleFuncBytes(v *[]byte) DecoderFunc {
 // is used in building a fluent API for decoding a tuple.
 type TupleDecoder struct {
 	decoders []DecoderFunc
+	// dynamic records whether any element is dynamic, which makes the
+	// tuple itself dynamic.
+	dynamic bool
+	// tails holds where the tail of each dynamic element ends, from which
+	// DecodeStrict finds the end of the tuple.
+	tails []tailEnd
+}
+
+// tailEnd gives the end of the tail of the element at index, relative to
+// the start of its tuple, once the element has been decoded from cur and
+// full.
+type tailEnd struct {
+	index int
+	end   func(cur, full []byte) int
 }
 
 // NewTupleDecoder creates a new TupleDecoder.
@@ -526,11 +1590,76 @@ func NewTupleDecoder() *TupleDecoder {
 	}
 }
 
+// NewTupleDecoderWithCapacity creates a new TupleDecoder with room for n
+// elements, which avoids growing the decoder as elements are added to
+// tuples with many elements.
+func NewTupleDecoderWithCapacity(n int) *TupleDecoder {
+	return &TupleDecoder{
+		decoders: make([]DecoderFunc, 0, max(n, 0)),
+	}
+}
+
 // Decode decodes the tuple.
 func (d *TupleDecoder) Decode(data []byte) error {
 	return DecodeTuple(data, d.decoders...)
 }
 
+// DecodeStrict decodes the tuple like Decode, but also rejects data that
+// extends beyond the end of the tuple, that is, beyond the head and the
+// furthest tail value of its elements, including those of nested tuples.
+// The end is worked out from the decoded offsets and lengths once the
+// tuple has been decoded, so the decoders run only once.  Only the
+// outermost tuple is checked for trailing bytes.
+func (d *TupleDecoder) DecodeStrict(data []byte) error {
+	err := d.Decode(data)
+	if err != nil {
+		return err
+	}
+
+	if end := d.end(data); end < len(data) {
+		format := "%d trailing bytes after the end of the tuple at %d"
+		return fmt.Errorf(format, len(data)-end, end)
+	}
+	return nil
+}
+
+// end returns the end of the tuple that was decoded from data, that is,
+// the end of its head or of the furthest tail of its elements.
+func (d *TupleDecoder) end(data []byte) int {
+	end := 32 * len(d.decoders)
+	for _, t := range d.tails {
+		cur := data[32*t.index : 32*(t.index+1)]
+		end = max(end, t.end(cur, data))
+	}
+	return end
+}
+
+// addTail records that the element about to be added is dynamic and that
+// the tail it points to ends at end.
+func (d *TupleDecoder) addTail(end func(cur, full []byte) int) {
+	d.tails = append(d.tails, tailEnd{index: len(d.decoders), end: end})
+	d.dynamic = true
+}
+
+// bytesEnd returns the end of the tail of bytes whose offset is cur, which
+// has already been decoded, and so validated, from full.
+func bytesEnd(cur, full []byte) int {
+	offset, _ := DecodeUint64(cur)
+	length, _ := DecodeUint64(full[offset : offset+32])
+	alignedLen, _ := nextMultipleOf32(int(length))
+	return int(offset) + 32 + alignedLen
+}
+
+// DecodeToStruct decodes a tuple into the fields of a struct.  The build
+// callback registers the fields as targets on the given TupleDecoder, and
+// then the tuple is decoded.  It is shorthand for building a TupleDecoder
+// and calling Decode.
+func DecodeToStruct(data []byte, build func(d *TupleDecoder)) error {
+	d := NewTupleDecoder()
+	build(d)
+	return d.Decode(data)
+}
+
 // Uint64 decodes a uint64 as the k-th element of a tuple.
 func (d *TupleDecoder) Uint64(v *uint64) *TupleDecoder {
 	decoder := DecodeTupleFuncUint64(v)
@@ -538,9 +1667,116 @@ func (d *TupleDecoder) Uint64(v *uint64) *TupleDecoder {
 	return d
 }
 
+// Uint8 decodes a uint8 as the k-th element of a tuple.
+func (d *TupleDecoder) Uint8(v *uint8) *TupleDecoder {
+	decoder := DecodeTupleFuncUint8(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Uint16 decodes a uint16 as the k-th element of a tuple.
+func (d *TupleDecoder) Uint16(v *uint16) *TupleDecoder {
+	decoder := DecodeTupleFuncUint16(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Uint32 decodes a uint32 as the k-th element of a tuple.
+func (d *TupleDecoder) Uint32(v *uint32) *TupleDecoder {
+	decoder := DecodeTupleFuncUint32(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
 // Bytes decodes a byte slice as the k-th element of a tuple.
 func (d *TupleDecoder) Bytes(v *[]byte) *TupleDecoder {
 	decoder := DecodeTupleFuncBytes(v)
+	d.addTail(bytesEnd)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// String decodes a string as the k-th element of a tuple.
+func (d *TupleDecoder) String(v *string) *TupleDecoder {
+	decoder := DecodeTupleFuncString(v)
+	d.addTail(bytesEnd)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// FixedUint64Array decodes a fixed-size array of len(v) uint64 values as
+// the k-th element of a tuple.
+func (d *TupleDecoder) FixedUint64Array(v []uint64) *TupleDecoder {
+	decoders := DecodeTupleFuncsFixedUint64Array(v)
+	d.decoders = append(d.decoders, decoders...)
+	return d
+}
+
+// FixedBytes32Array decodes a bytes32[len(v)] as the k-th element of a
+// tuple.
+func (d *TupleDecoder) FixedBytes32Array(v [][32]byte) *TupleDecoder {
+	decoders := DecodeTupleFuncsFixedBytes32Array(v)
+	d.decoders = append(d.decoders, decoders...)
+	return d
+}
+
+// Address decodes an address as the k-th element of a tuple.
+func (d *TupleDecoder) Address(v *[20]byte) *TupleDecoder {
+	decoder := DecodeTupleFuncAddress(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Bool decodes a bool as the k-th element of a tuple.
+func (d *TupleDecoder) Bool(v *bool) *TupleDecoder {
+	decoder := DecodeTupleFuncBool(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Bytes32 decodes a bytes32 as the k-th element of a tuple.
+func (d *TupleDecoder) Bytes32(v *[32]byte) *TupleDecoder {
+	decoder := DecodeTupleFuncBytes32(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Uint256 decodes a uint256 as the k-th element of a tuple into v, which
+// must not be nil.
+func (d *TupleDecoder) Uint256(v *big.Int) *TupleDecoder {
+	decoder := DecodeTupleFuncUint256(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Int256 decodes an int256 as the k-th element of a tuple into v, which
+// must not be nil.
+func (d *TupleDecoder) Int256(v *big.Int) *TupleDecoder {
+	decoder := DecodeTupleFuncInt256(v)
+	d.decoders = append(d.decoders, decoder)
+	return d
+}
+
+// Tuple decodes a nested tuple, whose elements are added by build, as the
+// k-th element of a tuple.  Whether the nested tuple is stored in the tail
+// or inline is decided as for TupleEncoder.Tuple, that is, by whether any
+// of its elements is dynamic.  A dynamic nested tuple is decoded by
+// following its offset, while the elements of a static nested tuple are
+// decoded from consecutive head slots of the enclosing tuple.
+func (d *TupleDecoder) Tuple(build func(d *TupleDecoder)) *TupleDecoder {
+	inner := NewTupleDecoder()
+	build(inner)
+
+	if !inner.dynamic {
+		d.decoders = append(d.decoders, inner.decoders...)
+		return d
+	}
+
+	decoder := DecodeTupleFuncTuple(inner.decoders...)
+	d.addTail(func(cur, full []byte) int {
+		offset, _ := DecodeUint64(cur)
+		return int(offset) + inner.end(full[offset:])
+	})
 	d.decoders = append(d.decoders, decoder)
 	return d
 }
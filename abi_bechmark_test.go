@@ -2,6 +2,7 @@ package abi
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 )
 
@@ -24,6 +25,26 @@ func BenchmarkEncodeUint64(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeUint64Into(b *testing.B) {
+	cases := []struct {
+		name string
+		v    uint64
+	}{
+		{"Small", 1},
+		{"Medium", 123456789},
+		{"Large", 1<<63 - 1},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			dst := make([]byte, 32)
+			for b.Loop() {
+				_ = EncodeUint64Into(dst, tc.v)
+			}
+		})
+	}
+}
+
 func BenchmarkDecodeUint64(b *testing.B) {
 	cases := []struct {
 		name string
@@ -43,6 +64,49 @@ func BenchmarkDecodeUint64(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeInteger(b *testing.B) {
+	data := EncodeUint64(200)
+
+	b.Run("Uint8", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = DecodeInteger[uint8](data)
+		}
+	})
+	b.Run("Int64", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = DecodeInteger[int64](data)
+		}
+	})
+}
+
+func BenchmarkDecodeBytes(b *testing.B) {
+	for _, size := range []int{32, 1024, 64 * 1024} {
+		encoded, err := EncodeBytes(bytes.Repeat([]byte{0xab}, size))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("Alloc/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				_, _ = DecodeBytes(encoded)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Into/%d", size), func(b *testing.B) {
+			dst := make([]byte, size)
+			for b.Loop() {
+				_, _ = DecodeBytesInto(encoded, dst)
+			}
+		})
+
+		b.Run(fmt.Sprintf("NoCopy/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				_, _ = DecodeBytesNoCopy(encoded)
+			}
+		})
+	}
+}
+
 func BenchmarkEncodeSliceOfBytes(b *testing.B) {
 	cases := []struct {
 		name string
@@ -285,3 +349,113 @@ func BenchmarkDecodeTupleFuncBytes(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkDecodeSliceOfBytesParallel(b *testing.B) {
+	arr := make([][]byte, 1000)
+	for i := range arr {
+		arr[i] = bytes.Repeat([]byte{1}, 256)
+	}
+	data, _ := EncodeSliceOfBytes(arr)
+
+	b.Run("Sequential-1000-items", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = DecodeSliceOfBytes(data)
+		}
+	})
+
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("Parallel-%d-workers-1000-items", workers), func(b *testing.B) {
+			for b.Loop() {
+				_, _ = DecodeSliceOfBytesParallel(data, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeTupleWithArena(b *testing.B) {
+	encoded, err := NewTupleEncoder().
+		Bytes(bytes.Repeat([]byte{1}, 40)).
+		Uint64(7).
+		Bytes(bytes.Repeat([]byte{2}, 200)).
+		Encode()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var first, second []byte
+	var u uint64
+
+	b.Run("Alloc", func(b *testing.B) {
+		for b.Loop() {
+			_ = DecodeTuple(encoded,
+				DecodeTupleFuncBytes(&first),
+				DecodeTupleFuncUint64(&u),
+				DecodeTupleFuncBytes(&second),
+			)
+		}
+	})
+
+	b.Run("Arena", func(b *testing.B) {
+		// the arena is reset every 1000 tuples, as a server would after
+		// handling a batch
+		arena := NewArena(1000 * 240)
+		decoders := []DecoderFunc{
+			DecodeTupleFuncBytesArena(&first, arena),
+			DecodeTupleFuncUint64(&u),
+			DecodeTupleFuncBytesArena(&second, arena),
+		}
+		i := 0
+		for b.Loop() {
+			if i%1000 == 0 {
+				arena.Reset()
+			}
+			i++
+			_ = DecodeTupleWithArena(encoded, arena, decoders...)
+		}
+	})
+}
+
+func BenchmarkNewTupleEncoder(b *testing.B) {
+	const n = 100
+
+	b.Run("NewTupleEncoder", func(b *testing.B) {
+		for b.Loop() {
+			e := NewTupleEncoder()
+			for i := range n {
+				e.Uint64(uint64(i))
+			}
+		}
+	})
+
+	b.Run("NewTupleEncoderWithCapacity", func(b *testing.B) {
+		for b.Loop() {
+			e := NewTupleEncoderWithCapacity(n)
+			for i := range n {
+				e.Uint64(uint64(i))
+			}
+		}
+	})
+}
+
+func BenchmarkNewTupleDecoder(b *testing.B) {
+	const n = 100
+	var v uint64
+
+	b.Run("NewTupleDecoder", func(b *testing.B) {
+		for b.Loop() {
+			d := NewTupleDecoder()
+			for range n {
+				d.Uint64(&v)
+			}
+		}
+	})
+
+	b.Run("NewTupleDecoderWithCapacity", func(b *testing.B) {
+		for b.Loop() {
+			d := NewTupleDecoderWithCapacity(n)
+			for range n {
+				d.Uint64(&v)
+			}
+		}
+	})
+}
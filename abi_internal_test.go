@@ -2,6 +2,7 @@ package abi
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,6 +30,38 @@ func TestIsNonZero(t *testing.T) {
 	}
 }
 
+func TestCheckPadding(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{name: "empty", input: []byte{}},
+		{name: "zeros", input: []byte{0, 0, 0}},
+		{
+			name:  "first byte",
+			input: []byte{0xff, 0, 0},
+			want:  "padding contains non-zero values at index 0 (0xff)",
+		},
+		{
+			name:  "first of several",
+			input: []byte{0, 0, 0, 7, 1},
+			want:  "padding contains non-zero values at index 3 (0x07)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			err := checkPadding(tc.input)
+			// then
+			if tc.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+}
+
 func TestPadRight(t *testing.T) {
 	fourBytes := []byte{15, 16, 23, 42}
 
@@ -76,6 +109,58 @@ func TestPadRight(t *testing.T) {
 	})
 }
 
+func TestPadLeft(t *testing.T) {
+	fourBytes := []byte{15, 16, 23, 42}
+
+	for _, tc := range []struct {
+		name      string
+		input     []byte
+		targetLen int
+		want      []byte
+	}{
+		{
+			name:      "more than 1 smaller than target length",
+			input:     fourBytes,
+			targetLen: 10,
+			want:      append([]byte{0, 0, 0, 0, 0, 0}, fourBytes...),
+		}, {
+			name:      "1 smaller than target length",
+			input:     fourBytes,
+			targetLen: 5,
+			want:      append([]byte{0}, fourBytes...),
+		}, {
+			name:      "same as target length",
+			input:     fourBytes,
+			targetLen: 4,
+			want:      fourBytes,
+		}, {
+			name:      "empty input",
+			input:     []byte{},
+			targetLen: 2,
+			want:      []byte{0, 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := padLeft(tc.input, tc.targetLen)
+
+			// then
+			require.NoError(t, err)
+			assert.Len(t, got, tc.targetLen)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("input is larger than target", func(t *testing.T) {
+		// given
+		input := fourBytes
+		// when
+		_, err := padLeft(input, 3)
+		// then
+		assert.ErrorContains(t, err, "smaller than input")
+	})
+}
+
 func TestNextMultipleOf32(t *testing.T) {
 	for _, tc := range []struct {
 		start int
@@ -92,10 +177,132 @@ func TestNextMultipleOf32(t *testing.T) {
 		t.Run(tcName, func(t *testing.T) {
 			for i := tc.start; i <= tc.end; i++ {
 				// when
-				got := nextMultipleOf32(i)
+				got, err := nextMultipleOf32(i)
 				// then
+				require.NoError(t, err)
 				assert.Equal(t, got, tc.want, "using value %d", i)
 			}
 		})
 	}
+
+	t.Run("largest value that fits", func(t *testing.T) {
+		// when
+		got, err := nextMultipleOf32(math.MaxInt - 31)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, math.MaxInt-31, got)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		for _, n := range []int{math.MaxInt - 30, math.MaxInt - 10, math.MaxInt} {
+			// when
+			got, err := nextMultipleOf32(n)
+			// then
+			assert.ErrorContains(t, err, "too large to pad to a multiple of 32")
+			assert.Zero(t, got)
+		}
+	})
+}
+
+func TestAddTupleSize(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		headSize, tailSize int
+		size               int
+		indirect           bool
+		wantHead, wantTail int
+	}{
+		{name: "static", headSize: 32, tailSize: 64, size: 64, wantHead: 96, wantTail: 64},
+		{name: "dynamic", headSize: 32, tailSize: 64, size: 96, indirect: true, wantHead: 64, wantTail: 160},
+		{
+			name:     "static at the limit",
+			headSize: 32, tailSize: 32, size: math.MaxInt - 64,
+			wantHead: math.MaxInt - 32, wantTail: 32,
+		},
+		{
+			name:     "dynamic at the limit",
+			headSize: 32, tailSize: 32, size: math.MaxInt - 96, indirect: true,
+			wantHead: 64, wantTail: math.MaxInt - 64,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			head, tail, err := addTupleSize(tc.headSize, tc.tailSize, tc.size, tc.indirect)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantHead, head)
+			assert.Equal(t, tc.wantTail, tail)
+		})
+	}
+
+	for _, tc := range []struct {
+		name               string
+		headSize, tailSize int
+		size               int
+		indirect           bool
+	}{
+		{name: "static past the limit", headSize: 32, tailSize: 32, size: math.MaxInt - 63},
+		{name: "dynamic past the limit", headSize: 32, tailSize: 32, size: math.MaxInt - 95, indirect: true},
+		{name: "dynamic offset past the limit", headSize: math.MaxInt - 31, size: 0, indirect: true},
+		{name: "max int", size: math.MaxInt, indirect: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, _, err := addTupleSize(tc.headSize, tc.tailSize, tc.size, tc.indirect)
+			// then
+			assert.ErrorContains(t, err, "tuple tail too large to encode")
+		})
+	}
+}
+
+func TestTupleSizes(t *testing.T) {
+	t.Run("head and tail", func(t *testing.T) {
+		// given
+		results := []EncoderResult{
+			{indirect: false, data: make([]byte, 64)},
+			{indirect: true, data: make([]byte, 96)},
+			{indirect: false, data: make([]byte, 32)},
+		}
+		// when
+		head, tail, err := tupleSizes(results)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 128, head)
+		assert.Equal(t, 96, tail)
+	})
+
+	t.Run("two results that together overflow", func(t *testing.T) {
+		// given
+		// the sizes that tupleSizes accumulates for two dynamic results
+		// of math.MaxInt/2 bytes each, as EncodedSize would see them
+		head, tail, err := addTupleSize(0, 0, math.MaxInt/2, true)
+		require.NoError(t, err)
+		// when
+		_, _, err = addTupleSize(head, tail, math.MaxInt/2, true)
+		// then
+		assert.ErrorContains(t, err, "tuple tail too large to encode")
+	})
+}
+
+func TestType_HasNestedOffsets(t *testing.T) {
+	for _, tc := range []struct {
+		typ  Type
+		want bool
+	}{
+		{typ: BytesType(), want: false},
+		{typ: SliceType(UintType(256)), want: false},
+		{typ: ArrayType(AddressType(), 2), want: false},
+		{typ: TupleType(UintType(256), BoolType()), want: false},
+		{typ: SliceType(StringType()), want: true},
+		{typ: ArrayType(BytesType(), 2), want: true},
+		{typ: TupleType(UintType(256), BytesType()), want: true},
+		{typ: SliceType(TupleType(UintType(256), BytesType())), want: true},
+	} {
+		t.Run(tc.typ.String(), func(t *testing.T) {
+			// when
+			got := tc.typ.hasNestedOffsets()
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
 }
@@ -2,9 +2,16 @@ package abi_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +35,68 @@ func TestEncodeUint64(t *testing.T) {
 	})
 }
 
+func TestEncodeUint64Into(t *testing.T) {
+	t.Run("matches EncodeUint64", func(t *testing.T) {
+		// given
+		// the destination is reused, so stale bytes must be overwritten
+		dst := bytes.Repeat([]byte{0xff}, 32)
+
+		for _, v := range []uint64{0, 1, 123456789, math.MaxUint64} {
+			// when
+			err := abi.EncodeUint64Into(dst, v)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, abi.EncodeUint64(v), dst)
+		}
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		err := abi.EncodeUint64Into(make([]byte, 31), 1)
+		// then
+		assert.ErrorContains(t, err, "destination of 31 bytes must contain 32 bytes")
+	})
+}
+
+func TestDecodeUint64Bounded(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value uint64
+		max   uint64
+	}{
+		{name: "below max", value: 3, max: 4},
+		{name: "at max", value: 4, max: 4},
+		{name: "zero max", value: 0, max: 0},
+		{name: "max uint64", value: math.MaxUint64, max: math.MaxUint64},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := abi.DecodeUint64Bounded(abi.EncodeUint64(tc.value), tc.max)
+			require.NoError(t, err)
+			// then
+			assert.Equal(t, tc.value, got)
+		})
+	}
+
+	t.Run("above max", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeUint64Bounded(abi.EncodeUint64(5000), 4096)
+		// then
+		assert.ErrorContains(t, err, "value 5000 exceeds maximum 4096")
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(3)
+		input[0] = 1
+		// when
+		_, err := abi.DecodeUint64Bounded(input, 4)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
+
 func TestDecodeUint64(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
@@ -67,6 +136,19 @@ func TestDecodeUint64(t *testing.T) {
 		// then
 		assert.ErrorContains(t, err, "padding contains non-zero values")
 	})
+
+	for _, index := range []int{0, 7, 8, 15, 16, 23} {
+		t.Run(fmt.Sprintf("bad padding at index %d", index), func(t *testing.T) {
+			// given
+			input := append(nZeros(31), 3)
+			input[index] = 1
+			input[23] = 1
+			// when
+			_, err := abi.DecodeUint64(input)
+			// then
+			assert.ErrorContains(t, err, fmt.Sprintf("non-zero values at index %d", index))
+		})
+	}
 }
 
 func TestEncodeDecodeUint64Roundtrip(t *testing.T) {
@@ -118,6 +200,67 @@ func TestEncodeBytes(t *testing.T) {
 	})
 }
 
+func TestEmptyBytes(t *testing.T) {
+	t.Run("decodes as empty bytes", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeBytes(abi.EmptyBytes())
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []byte{}, got)
+	})
+
+	t.Run("results do not alias each other", func(t *testing.T) {
+		// given
+		first := abi.EmptyBytes()
+		encoded, err := abi.EncodeBytes([]byte{1})
+		require.NoError(t, err)
+		// when
+		first[0] = 0xff
+		encoded[63] = 0xff
+		// then
+		assert.Equal(t, nZeros(32), abi.EmptyBytes())
+		want, err := abi.EncodeBytes([]byte{1})
+		require.NoError(t, err)
+		assert.Zero(t, want[63])
+	})
+}
+
+func TestEncodeBytesWithPad(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		for _, n := range []int{0, 1, 31, 32, 33} {
+			// given
+			input := bytes.Repeat([]byte{7}, n)
+			want, err := abi.EncodeBytes(input)
+			require.NoError(t, err)
+			// when
+			got, err := abi.EncodeBytesWithPad(input, (n+31)/32*32)
+			require.NoError(t, err)
+			// then
+			assert.Equal(t, want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		name       string
+		inputLen   int
+		alignedLen int
+		want       string
+	}{
+		{name: "not aligned", inputLen: 5, alignedLen: 33, want: "not 32-byte aligned"},
+		{name: "too short", inputLen: 33, alignedLen: 32, want: "inconsistent with input 33"},
+		{name: "too long", inputLen: 5, alignedLen: 64, want: "inconsistent with input 5"},
+		{name: "empty too long", inputLen: 0, alignedLen: 32, want: "inconsistent with input 0"},
+		{name: "negative", inputLen: 0, alignedLen: -32, want: "inconsistent with input 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := abi.EncodeBytesWithPad(nZeros(tc.inputLen), tc.alignedLen)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
 func TestDecodeBytes(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
@@ -251,7 +394,7 @@ func TestDecodeBytes(t *testing.T) {
 		_, err := abi.DecodeBytes(input)
 
 		// then
-		assert.ErrorContains(t, err, "padding contains non-zero values")
+		assert.ErrorContains(t, err, "padding contains non-zero values at index 30 (0x07)")
 	})
 }
 
@@ -276,6 +419,343 @@ func TestEncodeDecodeBytesRoundTrip(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeBytesRoundTrip_WordAligned(t *testing.T) {
+	// content whose length is a multiple of 32 fills the tail exactly, so
+	// there are no padding bytes after it
+	for _, n := range []int{32, 64, 96} {
+		t.Run(fmt.Sprintf("%d-bytes", n), func(t *testing.T) {
+			// given
+			input := bytes.Repeat([]byte{0xab}, n)
+
+			// when
+			encoded, err := abi.EncodeBytes(input)
+			require.NoError(t, err)
+			require.Len(t, encoded, 32+n)
+
+			got, err := abi.DecodeBytes(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+}
+
+func TestDecodeBytesInto(t *testing.T) {
+	input, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		dst := make([]byte, 8)
+		// when
+		n, err := abi.DecodeBytesInto(input, dst)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, 5, n)
+		assert.Equal(t, []byte("hello"), dst[:n])
+	})
+
+	t.Run("destination of exact size", func(t *testing.T) {
+		// given
+		dst := make([]byte, 5)
+		// when
+		n, err := abi.DecodeBytesInto(input, dst)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []byte("hello"), dst[:n])
+	})
+
+	t.Run("empty bytes", func(t *testing.T) {
+		// given
+		empty, err := abi.EncodeBytes(nil)
+		require.NoError(t, err)
+		// when
+		n, err := abi.DecodeBytesInto(empty, nil)
+		require.NoError(t, err)
+		// then
+		assert.Zero(t, n)
+	})
+
+	t.Run("destination too small", func(t *testing.T) {
+		// given
+		dst := make([]byte, 4)
+		// when
+		_, err := abi.DecodeBytesInto(input, dst)
+		// then
+		assert.ErrorContains(t, err, "destination of 4 bytes too small for 5 bytes of data")
+		assert.Equal(t, make([]byte, 4), dst)
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeBytesInto(input[:32], make([]byte, 8))
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeBytesNoCopy(t *testing.T) {
+	t.Run("aliases the input", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeBytesNoCopy(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []byte("hello"), got)
+		input[32] = 'j'
+		assert.Equal(t, []byte("jello"), got)
+	})
+
+	t.Run("append does not overwrite the padding", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeBytesNoCopy(input)
+		require.NoError(t, err)
+		_ = append(got, '!')
+		// then
+		assert.Equal(t, 5, cap(got))
+		assert.NoError(t, abi.ValidateBytesEncoding(input))
+	})
+
+	valid, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+	}{
+		{name: "valid", input: valid},
+		{name: "too short", input: valid[:31]},
+		{name: "length out of range", input: valid[:32]},
+		{name: "non-zero padding", input: append(valid[:63:63], 1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			want, wantErr := abi.DecodeBytes(tc.input)
+			// when
+			got, err := abi.DecodeBytesNoCopy(tc.input)
+			// then
+			assert.Equal(t, wantErr, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestValidateBytesEncoding(t *testing.T) {
+	valid, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+
+	corrupt := func(f func([]byte) []byte) []byte {
+		return f(append([]byte{}, valid...))
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		ok    bool
+	}{
+		{name: "valid", input: valid, ok: true},
+		{name: "too short", input: valid[:31]},
+		{name: "not 32-byte aligned", input: valid[:40]},
+		{name: "length out of range", input: valid[:32]},
+		{name: "invalid length", input: corrupt(func(b []byte) []byte { b[0] = 1; return b })},
+		{name: "non-zero padding", input: corrupt(func(b []byte) []byte { b[63] = 1; return b })},
+		{
+			name:  "too much padding",
+			input: corrupt(func(b []byte) []byte { return append(b, nZeros(32)...) }),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			_, want := abi.DecodeBytes(tc.input)
+			// when
+			err := abi.ValidateBytesEncoding(tc.input)
+			// then
+			assert.Equal(t, tc.ok, err == nil)
+			assert.Equal(t, want, err)
+		})
+	}
+}
+
+func TestValidateSliceOfBytesEncoding(t *testing.T) {
+	valid, err := abi.EncodeSliceOfBytes([][]byte{[]byte("hello"), []byte("world")})
+	require.NoError(t, err)
+
+	corrupt := func(f func([]byte) []byte) []byte {
+		return f(append([]byte{}, valid...))
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		ok    bool
+	}{
+		{name: "valid", input: valid, ok: true},
+		{name: "too short", input: valid[:63]},
+		{name: "not a slice type", input: corrupt(func(b []byte) []byte { b[0] = 1; return b })},
+		{name: "too many elements", input: valid[:96]},
+		{name: "offset out of bounds", input: corrupt(func(b []byte) []byte { b[95] = 0xff; return b })},
+		{
+			name:  "element padding",
+			input: corrupt(func(b []byte) []byte { b[len(b)-1] = 1; return b }),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			_, want := abi.DecodeSliceOfBytes(tc.input)
+			// when
+			err := abi.ValidateSliceOfBytesEncoding(tc.input)
+			// then
+			assert.Equal(t, tc.ok, err == nil)
+			assert.Equal(t, want, err)
+		})
+	}
+
+	t.Run("slice test data", func(t *testing.T) {
+		for _, tc := range testData.sliceOfBytes {
+			assert.NoError(t, abi.ValidateSliceOfBytesEncoding(tc.encoded), tc.name)
+		}
+	})
+}
+
+func TestDecodeBytesWithOptions(t *testing.T) {
+	input, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+
+	t.Run("within limits", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeBytesWithOptions(input, abi.DecodeOptions{MaxBytes: 5})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello"), got)
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeBytesWithOptions(input, abi.DecodeOptions{})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello"), got)
+	})
+
+	t.Run("over MaxBytes", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeBytesWithOptions(input, abi.DecodeOptions{MaxBytes: 4})
+		// then
+		assert.ErrorContains(t, err, "length 5 exceeds limit 4")
+	})
+
+	t.Run("over MaxTotalBytes", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeBytesWithOptions(input, abi.DecodeOptions{MaxTotalBytes: 4})
+		// then
+		assert.ErrorContains(t, err, "total decoded size exceeds limit")
+	})
+}
+
+func TestDecodeBytesN(t *testing.T) {
+	t.Run("concatenated values", func(t *testing.T) {
+		// given
+		first, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		second, err := abi.EncodeBytes(bytes.Repeat([]byte{0xab}, 40))
+		require.NoError(t, err)
+		input := append(append([]byte{}, first...), second...)
+
+		// when
+		got1, n1, err := abi.DecodeBytesN(input)
+		require.NoError(t, err)
+		got2, n2, err := abi.DecodeBytesN(input[n1:])
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []byte("hello"), got1)
+		assert.Equal(t, 64, n1)
+		assert.Equal(t, bytes.Repeat([]byte{0xab}, 40), got2)
+		assert.Equal(t, 96, n2)
+		assert.Equal(t, len(input), n1+n2)
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		// given
+		input := append(abi.EncodeUint64(0), abi.EncodeUint64(7)...)
+
+		// when
+		got, n, err := abi.DecodeBytesN(input)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []byte{}, got)
+		assert.Equal(t, 32, n)
+	})
+
+	t.Run("length out of range", func(t *testing.T) {
+		// given
+		input := append(abi.EncodeUint64(33), nZeros(32)...)
+
+		// when
+		_, _, err := abi.DecodeBytesN(input)
+
+		// then
+		assert.ErrorContains(t, err, "length in head is out of range")
+	})
+
+	t.Run("padding out of range", func(t *testing.T) {
+		// given
+		input := append(abi.EncodeUint64(5), []byte("hello")...)
+
+		// when
+		_, _, err := abi.DecodeBytesN(input)
+
+		// then
+		assert.ErrorContains(t, err, "padding is out of range")
+	})
+
+	t.Run("non-zero padding", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		input[len(input)-1] = 1
+
+		// when
+		_, _, err = abi.DecodeBytesN(input)
+
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
+
+func TestLooksDoubleEncoded(t *testing.T) {
+	encoded, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+	doubleEncoded, err := abi.EncodeBytes(encoded)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		want  bool
+	}{
+		{name: "raw value", input: []byte("hello"), want: false},
+		{name: "raw 32-byte aligned value", input: bytes.Repeat([]byte{1}, 64), want: false},
+		{name: "encoded value", input: encoded, want: true},
+		{name: "double encoded value", input: doubleEncoded, want: true},
+		{name: "zero word", input: nZeros(32), want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got := abi.LooksDoubleEncoded(tc.input)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestEncodeSliceOfBytes(t *testing.T) {
 	for _, tc := range testData.sliceOfBytes {
 		t.Run(tc.name, func(t *testing.T) {
@@ -303,6 +783,41 @@ func TestDecodeSliceOfBytes(t *testing.T) {
 		})
 	}
 
+	t.Run("element count whose offsets overflow", func(t *testing.T) {
+		// given
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(1<<59)...)
+		input = append(input, nZeros(64)...)
+		// when
+		var err error
+		require.NotPanics(t, func() { _, err = abi.DecodeSliceOfBytes(input) })
+		// then
+		assert.ErrorContains(t, err, "tail too short")
+	})
+
+	t.Run("offset of zero points into the offset table", func(t *testing.T) {
+		// given
+		// the offset word doubles as the length word of an empty element
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(1)...)
+		input = append(input, abi.EncodeUint64(0)...)
+		// when
+		_, err := abi.DecodeSliceOfBytes(input)
+		// then
+		assert.ErrorContains(t, err, "offset at index 0 points into the offset table")
+	})
+
+	t.Run("offset points into the middle of the offset table", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeSliceOfBytes([][]byte{{}, {}})
+		require.NoError(t, err)
+		copy(input[64:96], abi.EncodeUint64(32))
+		// when
+		_, err = abi.DecodeSliceOfBytes(input)
+		// then
+		assert.ErrorContains(t, err, "offset at index 0 points into the offset table")
+	})
+
 	t.Run("too short to have a header", func(t *testing.T) {
 		// given
 		input := []byte("too-short")
@@ -429,7 +944,245 @@ func TestDecodeSliceOfBytes(t *testing.T) {
 	})
 }
 
-func TestEncodeDecodeSliceOfBytesRoundTrip(t *testing.T) {
+func TestRangeSliceOfBytes(t *testing.T) {
+	collect := func(input []byte) ([][]byte, error) {
+		got := [][]byte{}
+		err := abi.RangeSliceOfBytes(input, func(i int, elem []byte) bool {
+			assert.Equal(t, len(got), i)
+			got = append(got, elem)
+			return true
+		})
+		return got, err
+	}
+
+	for _, tc := range testData.sliceOfBytes {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			want, err := abi.DecodeSliceOfBytes(tc.encoded)
+			require.NoError(t, err)
+			// when
+			got, err := collect(tc.encoded)
+			require.NoError(t, err)
+			// then
+			assert.Equal(t, want, got)
+		})
+	}
+
+	valid, err := abi.EncodeSliceOfBytes([][]byte{[]byte("hello"), []byte("world")})
+	require.NoError(t, err)
+
+	t.Run("stops early", func(t *testing.T) {
+		// given
+		var got [][]byte
+		// when
+		err := abi.RangeSliceOfBytes(valid, func(_ int, elem []byte) bool {
+			got = append(got, elem)
+			return false
+		})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("hello")}, got)
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		// when
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = abi.RangeSliceOfBytes(valid, func(int, []byte) bool { return true })
+		})
+		// then
+		assert.Zero(t, allocs)
+	})
+
+	corrupt := func(f func([]byte) []byte) []byte {
+		return f(append([]byte{}, valid...))
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+	}{
+		{name: "too short", input: valid[:63]},
+		{name: "not a slice type", input: corrupt(func(b []byte) []byte { b[0] = 1; return b })},
+		{name: "too many elements", input: valid[:96]},
+		{name: "offset out of bounds", input: corrupt(func(b []byte) []byte { b[127] = 0xff; return b })},
+		{name: "offsets out of order", input: corrupt(func(b []byte) []byte { b[127] = 0x40; return b })},
+		{name: "offset into the table", input: corrupt(func(b []byte) []byte { b[127] = 0x20; return b })},
+		{
+			name:  "element padding",
+			input: corrupt(func(b []byte) []byte { b[len(b)-1] = 1; return b }),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			_, want := abi.DecodeSliceOfBytes(tc.input)
+			require.Error(t, want)
+			// when
+			_, err := collect(tc.input)
+			// then
+			assert.Equal(t, want, err)
+		})
+	}
+}
+
+func TestDecodeSliceOfBytesStrict(t *testing.T) {
+	for _, tc := range testData.sliceOfBytes {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := abi.DecodeSliceOfBytesStrict(tc.encoded)
+			require.NoError(t, err)
+			// then
+			assert.Equal(t, tc.native, got)
+		})
+	}
+
+	hi, err := abi.EncodeBytes([]byte("hi"))
+	require.NoError(t, err)
+
+	t.Run("first offset does not follow the offset table", func(t *testing.T) {
+		// given
+		// a zero word between the offset table and the only element
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(1)...)
+		input = append(input, abi.EncodeUint64(64)...)
+		input = append(input, nZeros(32)...)
+		input = append(input, hi...)
+
+		// when
+		lax, laxErr := abi.DecodeSliceOfBytes(input)
+		_, err := abi.DecodeSliceOfBytesStrict(input)
+
+		// then
+		require.NoError(t, laxErr)
+		assert.Equal(t, [][]byte{[]byte("hi")}, lax)
+		assert.ErrorContains(t, err, "offset at index 0 is 64, canonically 32")
+	})
+
+	t.Run("trailing bytes after an empty slice", func(t *testing.T) {
+		// given
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(0)...)
+		input = append(input, nZeros(32)...)
+
+		// when
+		_, laxErr := abi.DecodeSliceOfBytes(input)
+		_, err := abi.DecodeSliceOfBytesStrict(input)
+
+		// then
+		require.NoError(t, laxErr)
+		assert.ErrorContains(t, err, "32 trailing bytes after the last element")
+	})
+
+	t.Run("gapped offsets", func(t *testing.T) {
+		// given
+		// a zero word between the offset table and the first of two
+		// elements, which shifts both offsets past their canonical values
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(2)...)
+		input = append(input, abi.EncodeUint64(96)...)
+		input = append(input, abi.EncodeUint64(160)...)
+		input = append(input, nZeros(32)...)
+		input = append(input, hi...)
+		input = append(input, hi...)
+
+		// when
+		lax, laxErr := abi.DecodeSliceOfBytes(input)
+		_, err := abi.DecodeSliceOfBytesStrict(input)
+
+		// then
+		require.NoError(t, laxErr)
+		assert.Equal(t, [][]byte{[]byte("hi"), []byte("hi")}, lax)
+		assert.ErrorContains(t, err, "offset at index 0 is 96, canonically 64")
+	})
+
+	t.Run("gap between elements", func(t *testing.T) {
+		// given
+		// a zero word between the first and second elements, which the
+		// lax decoder also rejects, as it becomes part of the first
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(2)...)
+		input = append(input, abi.EncodeUint64(64)...)
+		input = append(input, abi.EncodeUint64(160)...)
+		input = append(input, hi...)
+		input = append(input, nZeros(32)...)
+		input = append(input, hi...)
+
+		// when
+		_, laxErr := abi.DecodeSliceOfBytes(input)
+		_, err := abi.DecodeSliceOfBytesStrict(input)
+
+		// then
+		assert.Equal(t, laxErr, err)
+		assert.ErrorContains(t, err, "decoding element 0, invalid padding length")
+	})
+}
+
+func TestDecodeSliceOfBytesWithOptions(t *testing.T) {
+	input, err := abi.EncodeSliceOfBytes([][]byte{[]byte("ab"), []byte("cd"), []byte("ef")})
+	require.NoError(t, err)
+
+	t.Run("within limits", func(t *testing.T) {
+		// given
+		opts := abi.DecodeOptions{MaxElements: 3, MaxBytes: 2, MaxTotalBytes: 6}
+		// when
+		got, err := abi.DecodeSliceOfBytesWithOptions(input, opts)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("ab"), []byte("cd"), []byte("ef")}, got)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			opts    abi.DecodeOptions
+			wantErr string
+		}{
+			{
+				name:    "over MaxElements",
+				opts:    abi.DecodeOptions{MaxElements: 2},
+				wantErr: "element count 3 exceeds limit 2",
+			},
+			{
+				name:    "over MaxBytes",
+				opts:    abi.DecodeOptions{MaxBytes: 1},
+				wantErr: "length 2 exceeds limit 1",
+			},
+			{
+				name:    "over MaxTotalBytes",
+				opts:    abi.DecodeOptions{MaxTotalBytes: 5},
+				wantErr: "total decoded size exceeds limit",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				_, err := abi.DecodeSliceOfBytesWithOptions(input, tc.opts)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+
+	t.Run("oversized element count is rejected before allocating", func(t *testing.T) {
+		// given a header claiming more elements than allowed, with a tail
+		// long enough to pass the length check
+		const count = 100_000
+		oversized := abi.SliceHeader()
+		oversized = append(oversized, abi.EncodeUint64(count)...)
+		oversized = append(oversized, make([]byte, 32*count)...)
+		opts := abi.DecodeOptions{MaxElements: 10}
+
+		// when
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := abi.DecodeSliceOfBytesWithOptions(oversized, opts)
+		runtime.ReadMemStats(&after)
+
+		// then
+		assert.ErrorContains(t, err, "exceeds limit")
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(count))
+	})
+}
+
+func TestEncodeDecodeSliceOfBytesRoundTrip(t *testing.T) {
 	for _, tc := range testData.sliceOfBytes {
 		t.Run(tc.name, func(t *testing.T) {
 			// when
@@ -443,6 +1196,172 @@ func TestEncodeDecodeSliceOfBytesRoundTrip(t *testing.T) {
 			assert.Equal(t, tc.native, got)
 		})
 	}
+
+	t.Run("nil and empty elements", func(t *testing.T) {
+		// given
+		input := [][]byte{nil, {}, {1}}
+
+		// when
+		encoded, err := abi.EncodeSliceOfBytes(input)
+		require.NoError(t, err)
+
+		got, err := abi.DecodeSliceOfBytes(encoded)
+		require.NoError(t, err)
+
+		// then
+		// nil and empty encode identically and both decode as empty
+		assert.Equal(t, [][]byte{{}, {}, {1}}, got)
+		for i := range got {
+			assert.NotNil(t, got[i])
+		}
+	})
+}
+
+func TestEncodeDecodeSliceOfStrings(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input []string
+	}{
+		{"empty slice", []string{}},
+		{"empty string element", []string{"a", "", "c"}},
+		{"multibyte utf-8", []string{"héllo", "日本語", "🦀"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			want, err := abi.EncodeValue(
+				abi.SliceType(abi.StringType()),
+				stringsToAny(tc.input),
+			)
+			require.NoError(t, err)
+
+			// when
+			encoded, err := abi.EncodeSliceOfStrings(tc.input)
+			require.NoError(t, err)
+			got, err := abi.DecodeSliceOfStrings(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, want, encoded)
+			assert.Equal(t, tc.input, got)
+		})
+	}
+
+	t.Run("invalid utf-8", func(t *testing.T) {
+		// given
+		encoded, err := abi.EncodeSliceOfBytes([][]byte{[]byte("ok"), {0xff, 0xfe}})
+		require.NoError(t, err)
+
+		// when
+		_, err = abi.DecodeSliceOfStrings(encoded)
+
+		// then
+		assert.ErrorContains(t, err, "decoding element 1, string is not valid utf-8")
+	})
+}
+
+func stringsToAny(v []string) []any {
+	out := make([]any, len(v))
+	for i := range v {
+		out[i] = v[i]
+	}
+	return out
+}
+
+func TestEncodeSliceOfUint64(t *testing.T) {
+	t.Run("matches schema encoding", func(t *testing.T) {
+		// given
+		input := []uint64{1, 0, math.MaxUint64}
+		want, err := abi.EncodeValue(
+			abi.SliceType(abi.UintType(256)),
+			[]any{uint64(1), uint64(0), uint64(math.MaxUint64)},
+		)
+		require.NoError(t, err)
+
+		// when
+		got := abi.EncodeSliceOfUint64(input)
+
+		// then
+		// the elements follow the count directly, without offsets
+		assert.Equal(t, want, got)
+		assert.Len(t, got, 64+3*32)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// when
+		got := abi.EncodeSliceOfUint64(nil)
+		// then
+		assert.Equal(t, append(abi.SliceHeader(), abi.EncodeUint64(0)...), got)
+	})
+}
+
+func TestDecodeSliceOfUint64(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, n := range []int{0, 1, 100} {
+			t.Run(fmt.Sprintf("%d-elements", n), func(t *testing.T) {
+				// given
+				input := make([]uint64, n)
+				for i := range input {
+					input[i] = uint64(i) * 1_000_003
+				}
+
+				// when
+				got, err := abi.DecodeSliceOfUint64(abi.EncodeSliceOfUint64(input))
+
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, input, got)
+			})
+		}
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		input := abi.EncodeSliceOfUint64([]uint64{1})
+		input[31] = 0x40
+		// when
+		_, err := abi.DecodeSliceOfUint64(input)
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("misaligned", func(t *testing.T) {
+		// given
+		input := append(abi.EncodeSliceOfUint64([]uint64{1, 2}), 0)
+		// when
+		_, err := abi.DecodeSliceOfUint64(input)
+		// then
+		assert.ErrorContains(t, err, "slice of 2 elements must contain 64 bytes")
+	})
+
+	t.Run("count larger than data", func(t *testing.T) {
+		// given
+		input := abi.EncodeSliceOfUint64([]uint64{1, 2})
+		copy(input[32:64], abi.EncodeUint64(3))
+		// when
+		_, err := abi.DecodeSliceOfUint64(input)
+		// then
+		assert.ErrorContains(t, err, "slice of 3 elements must contain 96 bytes")
+	})
+
+	t.Run("count whose size overflows", func(t *testing.T) {
+		// given 32*count wraps around to 0
+		input := abi.EncodeSliceOfUint64(nil)
+		copy(input[32:64], abi.EncodeUint64(1<<59))
+		// when
+		_, err := abi.DecodeSliceOfUint64(input)
+		// then
+		assert.ErrorContains(t, err, "element count 576460752303423488 out of range")
+	})
+
+	t.Run("element too large", func(t *testing.T) {
+		// given
+		input := abi.EncodeSliceOfUint64([]uint64{1, 2})
+		input[64+32] = 1
+		// when
+		_, err := abi.DecodeSliceOfUint64(input)
+		// then
+		assert.ErrorContains(t, err, "decoding element 1")
+	})
 }
 
 func TestEncodeDecodeTupleRoundTrip(t *testing.T) {
@@ -519,6 +1438,326 @@ func TestDecodeTuple(t *testing.T) {
 	})
 }
 
+func TestDecodeTupleStrict(t *testing.T) {
+	var a, b uint64
+	var c []byte
+	static := func(d *abi.TupleDecoder) { d.Uint64(&a).Uint64(&b) }
+	dynamic := func(d *abi.TupleDecoder) { d.Uint64(&a).Bytes(&c) }
+
+	staticInput, err := abi.EncodeTuple(
+		abi.EncodeTupleFuncUint64(1),
+		abi.EncodeTupleFuncUint64(2),
+	)
+	require.NoError(t, err)
+	dynamicInput, err := abi.EncodeTuple(
+		abi.EncodeTupleFuncUint64(1),
+		abi.EncodeTupleFuncBytes(bytes.Repeat([]byte{0xab}, 40)),
+	)
+	require.NoError(t, err)
+	nestedInput, err := abi.EncodeTuple(abi.EncodeTupleFuncTuple(
+		abi.EncodeTupleFuncUint64(1),
+		abi.EncodeTupleFuncBytes(bytes.Repeat([]byte{0xab}, 40)),
+	))
+	require.NoError(t, err)
+	nested := func(d *abi.TupleDecoder) { d.Tuple(dynamic) }
+
+	t.Run("happy path", func(t *testing.T) {
+		for _, tc := range []struct {
+			name  string
+			input []byte
+			build func(d *abi.TupleDecoder)
+		}{
+			{name: "static", input: staticInput, build: static},
+			{name: "dynamic", input: dynamicInput, build: dynamic},
+			{name: "nested", input: nestedInput, build: nested},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// given
+				a, b, c = 0, 0, nil
+				// when
+				err := abi.DecodeTupleStrict(tc.input, tc.build)
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, uint64(1), a)
+			})
+		}
+		assert.Equal(t, bytes.Repeat([]byte{0xab}, 40), c)
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			input   []byte
+			build   func(d *abi.TupleDecoder)
+			wantErr string
+		}{
+			{
+				name:    "static with trailing word",
+				input:   append(slices.Clone(staticInput), nZeros(32)...),
+				build:   static,
+				wantErr: "32 trailing bytes after the end of the tuple at 64",
+			},
+			{
+				name:    "dynamic with trailing words",
+				input:   append(slices.Clone(dynamicInput), nZeros(64)...),
+				build:   dynamic,
+				wantErr: "64 trailing bytes after the end of the tuple at 160",
+			},
+			{
+				name:    "nested tuple with trailing word",
+				input:   append(slices.Clone(nestedInput), nZeros(32)...),
+				build:   nested,
+				wantErr: "32 trailing bytes after the end of the tuple at 192",
+			},
+			{
+				name:    "unaligned",
+				input:   append(slices.Clone(staticInput), 0x01),
+				build:   static,
+				wantErr: "1 trailing bytes after the end of the tuple at 64",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				err := abi.DecodeTupleStrict(tc.input, tc.build)
+				// then
+				assert.EqualError(t, err, tc.wantErr)
+			})
+		}
+	})
+
+	t.Run("lenient decode accepts trailing bytes", func(t *testing.T) {
+		// given
+		input := append(slices.Clone(staticInput), nZeros(32)...)
+		// when
+		err := abi.DecodeToStruct(input, static)
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		// given
+		input := slices.Clone(dynamicInput)
+		input[63] = 0xff
+		// when
+		err := abi.DecodeTupleStrict(input, dynamic)
+		// then
+		var decodeErr *abi.DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, 1, decodeErr.ElementIndex)
+	})
+}
+
+func TestDecodeTupleCtx(t *testing.T) {
+	// a large tuple of bytes fields
+	const fields = 10_000
+	encoders := make([]abi.EncoderFunc, fields)
+	for i := range encoders {
+		encoders[i] = abi.EncodeTupleFuncBytes(bytes.Repeat([]byte{byte(i)}, 40))
+	}
+	input, err := abi.EncodeTuple(encoders...)
+	require.NoError(t, err)
+
+	got := make([][]byte, fields)
+	decoders := make([]abi.DecoderFunc, fields)
+	for i := range decoders {
+		decoders[i] = abi.DecodeTupleFuncBytes(&got[i])
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		err := abi.DecodeTupleCtx(context.Background(), input, decoders...)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, bytes.Repeat([]byte{byte((fields - 1) % 256)}, 40), got[fields-1])
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// when
+		err := abi.DecodeTupleCtx(ctx, input, decoders...)
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+		defer cancel()
+		// when
+		err := abi.DecodeTupleCtx(ctx, input, decoders...)
+		// then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("cancelled while decoding", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		count := 0
+		counting := make([]abi.DecoderFunc, fields)
+		for i := range counting {
+			counting[i] = func(cur, full []byte) error {
+				count++
+				if count == 100 {
+					cancel()
+				}
+				return decoders[i](cur, full)
+			}
+		}
+
+		// when
+		err := abi.DecodeTupleCtx(ctx, input, counting...)
+
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, count, 200)
+	})
+
+	t.Run("cancelled while decoding a nested tuple", func(t *testing.T) {
+		// given
+		nested, err := abi.EncodeTuple(abi.EncodeTupleFuncTuple(encoders...))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		count := 0
+		counting := make([]abi.DecoderFunc, fields)
+		for i := range counting {
+			counting[i] = func(cur, full []byte) error {
+				count++
+				if count == 100 {
+					cancel()
+				}
+				return decoders[i](cur, full)
+			}
+		}
+
+		// when
+		err = abi.DecodeTupleCtx(ctx, nested, abi.DecodeTupleFuncTupleCtx(ctx, counting...))
+
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, count, 200)
+	})
+}
+
+func TestTupleEncoder_String(t *testing.T) {
+	// when
+	got, err := abi.NewTupleEncoder().String("hello").Uint64(7).Encode()
+	// then
+	require.NoError(t, err)
+	want, err := abi.Encode(
+		[]abi.Type{abi.StringType(), abi.UintType(64)},
+		[]any{"hello", 7},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestTupleEncoderDecoder_StringAndBytes(t *testing.T) {
+	// given
+	want, err := abi.Encode(
+		[]abi.Type{abi.UintType(64), abi.StringType(), abi.BytesType()},
+		[]any{7, "héllo", []byte("world")},
+	)
+	require.NoError(t, err)
+
+	// when
+	encoded, err := abi.NewTupleEncoder().
+		Uint64(7).
+		String("héllo").
+		Bytes([]byte("world")).
+		Encode()
+	require.NoError(t, err)
+
+	var u uint64
+	var s string
+	var b []byte
+	err = abi.NewTupleDecoder().Uint64(&u).String(&s).Bytes(&b).Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, want, encoded)
+	assert.Equal(t, uint64(7), u)
+	assert.Equal(t, "héllo", s)
+	assert.Equal(t, []byte("world"), b)
+}
+
+func TestTupleEncoder_Raw(t *testing.T) {
+	want, err := abi.NewTupleEncoder().
+		Uint64(7).
+		Bytes([]byte("hello")).
+		Bool(true).
+		Encode()
+	require.NoError(t, err)
+
+	rawBytes, err := abi.EncodeBytes([]byte("hello"))
+	require.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.NewTupleEncoder().
+			RawWord(abi.EncodeUint64(7)).
+			RawBytes(rawBytes).
+			RawWord(abi.EncodeBool(true)).
+			Encode()
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("word not 32 bytes", func(t *testing.T) {
+		// when
+		_, err := abi.NewTupleEncoder().RawWord(nZeros(31)).Encode()
+		// then
+		assert.ErrorContains(t, err, "raw word of 31 bytes must contain 32 bytes")
+	})
+
+	t.Run("invalid bytes encoding", func(t *testing.T) {
+		// given
+		invalid := append([]byte{}, rawBytes...)
+		invalid[len(invalid)-1] = 1
+		// when
+		_, err := abi.NewTupleEncoder().RawBytes(invalid).Encode()
+		// then
+		assert.ErrorContains(t, err, "validating raw bytes: padding contains non-zero values")
+	})
+}
+
+func TestDecodeTupleFuncString(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input, err := abi.Encode(
+			[]abi.Type{abi.StringType(), abi.UintType(64)},
+			[]any{"hello", 7},
+		)
+		require.NoError(t, err)
+		var gotString string
+		var gotUint uint64
+		// when
+		err = abi.NewTupleDecoder().String(&gotString).Uint64(&gotUint).Decode(input)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "hello", gotString)
+		assert.Equal(t, uint64(7), gotUint)
+	})
+
+	t.Run("invalid utf-8", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeTuple(abi.EncodeTupleFuncBytes([]byte{0xff, 0xfe}))
+		require.NoError(t, err)
+		var got string
+		// when
+		err = abi.DecodeTuple(input, abi.DecodeTupleFuncString(&got))
+		// then
+		assert.ErrorContains(t, err, "string is not valid utf-8")
+	})
+}
+
 func TestDecodeTupleFuncBytes(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
@@ -565,83 +1804,429 @@ func TestDecodeTupleFuncBytes(t *testing.T) {
 		// when
 		err := f(input[0:32], input)
 		// then
-		assert.ErrorContains(t, err, "decoding length")
+		assert.ErrorContains(t, err, "decoding length")
+	})
+
+	t.Run("end of offset out of bounds", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(32)
+		input = append(input, abiEncodeAByte(7)...)
+		f := abi.DecodeTupleFuncBytes(nil)
+		// when
+		err := f(input[0:32], input[:len(input)-1])
+		// then
+		assert.ErrorContains(t, err, "end is out of bounds")
+	})
+
+	t.Run("bytes are invalid", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(32)
+		input = append(input, abiEncodeAByte(7)...)
+		input[len(input)-1] = 1
+		f := abi.DecodeTupleFuncBytes(nil)
+		// when
+		err := f(input[0:32], input)
+		// then
+		assert.ErrorContains(t, err, "decoding bytes")
+	})
+
+	t.Run("arithmetic does not overflow", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			offset    uint64
+			byteCount uint64
+			wantErr   string
+		}{
+			{
+				name:      "offset near max uint64",
+				offset:    math.MaxUint64 - 31,
+				byteCount: 1,
+				wantErr:   "offset+32 out of bounds",
+			},
+			{
+				name:      "byte count near max uint64",
+				offset:    32,
+				byteCount: math.MaxUint64 - 31,
+				wantErr:   "end is out of bounds",
+			},
+			{
+				name:      "byte count near max int",
+				offset:    32,
+				byteCount: math.MaxInt - 10,
+				wantErr:   "end is out of bounds",
+			},
+			{
+				name:      "byte count one past the data",
+				offset:    32,
+				byteCount: 33,
+				wantErr:   "end is out of bounds",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// given
+				input := abi.EncodeUint64(tc.offset)
+				input = append(input, abi.EncodeUint64(tc.byteCount)...)
+				input = append(input, nZeros(32)...)
+				var got []byte
+				f := abi.DecodeTupleFuncBytes(&got)
+				// when
+				var err error
+				require.NotPanics(t, func() { err = f(input[0:32], input) })
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+func TestTupleEncoderDecoder_RoundTrip(t *testing.T) {
+	for _, tc := range testData.allInts {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			input := tc.native
+			encoded, err := abi.NewTupleEncoder().
+				Uint64(input.Val1).
+				Uint64(input.Val2).
+				Uint64(input.Val3).
+				Encode()
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, encoded)
+
+			var got AllInts
+			err = abi.NewTupleDecoder().
+				Uint64(&got.Val1).
+				Uint64(&got.Val2).
+				Uint64(&got.Val3).
+				Decode(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+
+	for _, tc := range testData.intAndBytes {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			input := tc.native
+			encoded, err := abi.NewTupleEncoder().
+				Uint64(input.Int1).
+				Bytes(input.Bytes1).
+				Bytes(input.Bytes2).
+				Encode()
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, encoded)
+
+			var got IntAndBytes
+			err = abi.NewTupleDecoder().
+				Uint64(&got.Int1).
+				Bytes(&got.Bytes1).
+				Bytes(&got.Bytes2).
+				Decode(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+}
+
+func TestNewTupleEncoderDecoderWithCapacity(t *testing.T) {
+	// given
+	want, err := abi.NewTupleEncoder().Uint64(1).Bytes([]byte("abc")).Encode()
+	require.NoError(t, err)
+
+	// when
+	got, err := abi.NewTupleEncoderWithCapacity(2).Uint64(1).Bytes([]byte("abc")).Encode()
+	require.NoError(t, err)
+
+	var u uint64
+	var b []byte
+	err = abi.NewTupleDecoderWithCapacity(2).Uint64(&u).Bytes(&b).Decode(got)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, want, got)
+	assert.Equal(t, uint64(1), u)
+	assert.Equal(t, []byte("abc"), b)
+}
+
+func TestTupleEncoderDecoder_Tuple(t *testing.T) {
+	t.Run("static nested tuple is inline", func(t *testing.T) {
+		// given
+		addr := [20]byte{0xaa}
+		schema := []abi.Type{
+			abi.UintType(64),
+			abi.TupleType(abi.UintType(64), abi.AddressType()),
+			abi.BytesType(),
+		}
+		want, err := abi.Encode(schema, []any{1, []any{2, addr}, []byte("abc")})
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			Uint64(1).
+			Tuple(func(e *abi.TupleEncoder) { e.Uint64(2).Address(addr) }).
+			Bytes([]byte("abc")).
+			Encode()
+		require.NoError(t, err)
+
+		var a, b uint64
+		var gotAddr [20]byte
+		var gotBytes []byte
+		err = abi.NewTupleDecoder().
+			Uint64(&a).
+			Tuple(func(d *abi.TupleDecoder) { d.Uint64(&b).Address(&gotAddr) }).
+			Bytes(&gotBytes).
+			Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		// the nested tuple takes up two head slots, so the bytes are at
+		// an offset of four words
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, abi.EncodeUint64(4*32), encoded[3*32:4*32])
+		assert.Equal(t, uint64(1), a)
+		assert.Equal(t, uint64(2), b)
+		assert.Equal(t, addr, gotAddr)
+		assert.Equal(t, []byte("abc"), gotBytes)
+	})
+
+	t.Run("dynamic nested tuple has an offset", func(t *testing.T) {
+		// given
+		schema := []abi.Type{
+			abi.UintType(64),
+			abi.TupleType(abi.UintType(64), abi.BytesType()),
+		}
+		want, err := abi.Encode(schema, []any{1, []any{2, []byte("abc")}})
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			Uint64(1).
+			Tuple(func(e *abi.TupleEncoder) { e.Uint64(2).Bytes([]byte("abc")) }).
+			Encode()
+		require.NoError(t, err)
+
+		var a, b uint64
+		var gotBytes []byte
+		err = abi.NewTupleDecoder().
+			Uint64(&a).
+			Tuple(func(d *abi.TupleDecoder) { d.Uint64(&b).Bytes(&gotBytes) }).
+			Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		// the nested tuple is in the tail, right after the two head slots
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, abi.EncodeUint64(2*32), encoded[32:64])
+		assert.Equal(t, uint64(1), a)
+		assert.Equal(t, uint64(2), b)
+		assert.Equal(t, []byte("abc"), gotBytes)
+	})
+
+	t.Run("doubly nested dynamic tuple", func(t *testing.T) {
+		// given
+		schema := []abi.Type{
+			abi.TupleType(abi.TupleType(abi.BytesType()), abi.UintType(64)),
+		}
+		want, err := abi.Encode(schema, []any{[]any{[]any{[]byte("x")}, 3}})
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			Tuple(func(e *abi.TupleEncoder) {
+				e.Tuple(func(e *abi.TupleEncoder) { e.Bytes([]byte("x")) }).Uint64(3)
+			}).
+			Encode()
+		require.NoError(t, err)
+
+		var gotBytes []byte
+		var u uint64
+		err = abi.NewTupleDecoder().
+			Tuple(func(d *abi.TupleDecoder) {
+				d.Tuple(func(d *abi.TupleDecoder) { d.Bytes(&gotBytes) }).Uint64(&u)
+			}).
+			Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, []byte("x"), gotBytes)
+		assert.Equal(t, uint64(3), u)
 	})
+}
 
-	t.Run("end of offset out of bounds", func(t *testing.T) {
-		// given
-		input := abi.EncodeUint64(32)
-		input = append(input, abiEncodeAByte(7)...)
-		f := abi.DecodeTupleFuncBytes(nil)
+func TestDecodeToStruct(t *testing.T) {
+	for _, tc := range testData.intAndBytes {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			var got IntAndBytes
+			err := abi.DecodeToStruct(tc.encoded, func(d *abi.TupleDecoder) {
+				d.Uint64(&got.Int1).Bytes(&got.Bytes1).Bytes(&got.Bytes2)
+			})
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, tc.native, got)
+		})
+	}
+
+	t.Run("no fields registered", func(t *testing.T) {
 		// when
-		err := f(input[0:32], input[:len(input)-1])
+		err := abi.DecodeToStruct(nZeros(32), func(*abi.TupleDecoder) {})
 		// then
-		assert.ErrorContains(t, err, "end is out of bounds")
+		assert.ErrorContains(t, err, "no decoders provided")
 	})
+}
 
-	t.Run("bytes are invalid", func(t *testing.T) {
+func TestEncodeTupleChecked(t *testing.T) {
+	types := []abi.Type{
+		abi.UintType(64),
+		abi.BytesType(),
+		abi.ArrayType(abi.UintType(64), 2),
+	}
+
+	t.Run("happy path", func(t *testing.T) {
 		// given
-		input := abi.EncodeUint64(32)
-		input = append(input, abiEncodeAByte(7)...)
-		input[len(input)-1] = 1
-		f := abi.DecodeTupleFuncBytes(nil)
+		encoders := []abi.EncoderFunc{
+			abi.EncodeTupleFuncUint64(1),
+			abi.EncodeTupleFuncBytes([]byte("hello")),
+			abi.EncodeTupleFuncFixedUint64Array([]uint64{2, 3}),
+		}
+		want, err := abi.EncodeTuple(encoders...)
+		require.NoError(t, err)
 		// when
-		err := f(input[0:32], input)
+		got, err := abi.EncodeTupleChecked(types, encoders...)
+		require.NoError(t, err)
 		// then
-		assert.ErrorContains(t, err, "decoding bytes")
+		assert.Equal(t, want, got)
 	})
+
+	for _, tc := range []struct {
+		name     string
+		encoders []abi.EncoderFunc
+		want     string
+	}{
+		{
+			name: "wrong number of encoders",
+			encoders: []abi.EncoderFunc{
+				abi.EncodeTupleFuncUint64(1),
+			},
+			want: "schema has 3 elements but got 1 encoders",
+		},
+		{
+			name: "dynamic where static expected",
+			encoders: []abi.EncoderFunc{
+				abi.EncodeTupleFuncBytes([]byte("hello")),
+				abi.EncodeTupleFuncBytes([]byte("hello")),
+				abi.EncodeTupleFuncFixedUint64Array([]uint64{2, 3}),
+			},
+			want: "field 0: expected static, got dynamic",
+		},
+		{
+			name: "static where dynamic expected",
+			encoders: []abi.EncoderFunc{
+				abi.EncodeTupleFuncUint64(1),
+				abi.EncodeTupleFuncUint64(1),
+				abi.EncodeTupleFuncFixedUint64Array([]uint64{2, 3}),
+			},
+			want: "field 1: expected dynamic, got static",
+		},
+		{
+			name: "static of wrong size",
+			encoders: []abi.EncoderFunc{
+				abi.EncodeTupleFuncUint64(1),
+				abi.EncodeTupleFuncBytes([]byte("hello")),
+				abi.EncodeTupleFuncFixedUint64Array([]uint64{2, 3, 4}),
+			},
+			want: "field 2: expected 64 bytes, got 96",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := abi.EncodeTupleChecked(types, tc.encoders...)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
 }
 
-func TestTupleEncoderDecoder_RoundTrip(t *testing.T) {
+func TestEncodeTuple_MatchesTupleEncoder(t *testing.T) {
+	// EncodeTuple and the TupleEncoder are two ways of building the same
+	// encoding, so they must always agree
 	for _, tc := range testData.allInts {
 		t.Run(tc.name, func(t *testing.T) {
-			// when
+			// given
 			input := tc.native
-			encoded, err := abi.NewTupleEncoder().
+
+			// when
+			variadic, err := abi.EncodeTuple(
+				abi.EncodeTupleFuncUint64(input.Val1),
+				abi.EncodeTupleFuncUint64(input.Val2),
+				abi.EncodeTupleFuncUint64(input.Val3),
+			)
+			require.NoError(t, err)
+
+			fluent, err := abi.NewTupleEncoder().
 				Uint64(input.Val1).
 				Uint64(input.Val2).
 				Uint64(input.Val3).
 				Encode()
 			require.NoError(t, err)
-			require.Equal(t, tc.encoded, encoded)
-
-			var got AllInts
-			err = abi.NewTupleDecoder().
-				Uint64(&got.Val1).
-				Uint64(&got.Val2).
-				Uint64(&got.Val3).
-				Decode(encoded)
-			require.NoError(t, err)
 
 			// then
-			assert.Equal(t, input, got)
+			assert.Equal(t, variadic, fluent)
 		})
 	}
 
 	for _, tc := range testData.intAndBytes {
 		t.Run(tc.name, func(t *testing.T) {
-			// when
+			// given
 			input := tc.native
-			encoded, err := abi.NewTupleEncoder().
+
+			// when
+			variadic, err := abi.EncodeTuple(
+				abi.EncodeTupleFuncUint64(input.Int1),
+				abi.EncodeTupleFuncBytes(input.Bytes1),
+				abi.EncodeTupleFuncBytes(input.Bytes2),
+			)
+			require.NoError(t, err)
+
+			fluent, err := abi.NewTupleEncoder().
 				Uint64(input.Int1).
 				Bytes(input.Bytes1).
 				Bytes(input.Bytes2).
 				Encode()
 			require.NoError(t, err)
-			require.Equal(t, tc.encoded, encoded)
-
-			var got IntAndBytes
-			err = abi.NewTupleDecoder().
-				Uint64(&got.Int1).
-				Bytes(&got.Bytes1).
-				Bytes(&got.Bytes2).
-				Decode(encoded)
-			require.NoError(t, err)
 
 			// then
-			assert.Equal(t, input, got)
+			assert.Equal(t, variadic, fluent)
 		})
 	}
+
+	t.Run("fixed array", func(t *testing.T) {
+		// given
+		input := []uint64{1, 2, 3}
+
+		// when
+		variadic, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncBytes([]byte("before")),
+			abi.EncodeTupleFuncFixedUint64Array(input),
+			abi.EncodeTupleFuncUint64(4),
+		)
+		require.NoError(t, err)
+
+		fluent, err := abi.NewTupleEncoder().
+			Bytes([]byte("before")).
+			FixedUint64Array(input).
+			Uint64(4).
+			Encode()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, variadic, fluent)
+	})
 }
 
 func ExampleTupleEncoder() {
@@ -730,3 +2315,676 @@ func ExampleEncodeSliceOfBytes() {
 	fmt.Printf("Roundtrip successful: %t\n", success)
 	// Output: Roundtrip successful: true
 }
+
+// nestedDynamicTuple is encoded by go-ethereum as the tuple
+// (uint256, (uint256, bytes), bytes).  Note that the offset of the
+// inner bytes is relative to the start of the inner tuple.
+var nestedDynamicTuple = hexDecode("" +
+	"0000000000000000000000000000000000000000000000000000000000000007" +
+	"0000000000000000000000000000000000000000000000000000000000000060" +
+	"00000000000000000000000000000000000000000000000000000000000000e0" +
+	"0000000000000000000000000000000000000000000000000000000000000008" +
+	"0000000000000000000000000000000000000000000000000000000000000040" +
+	"0000000000000000000000000000000000000000000000000000000000000005" +
+	"696e6e6572000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000005" +
+	"6f75746572000000000000000000000000000000000000000000000000000000",
+)
+
+func TestEncodeDecodeNestedTupleRoundTrip(t *testing.T) {
+	t.Run("dynamic nested tuple", func(t *testing.T) {
+		// when
+		encoded, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncUint64(7),
+			abi.EncodeTupleFuncTuple(
+				abi.EncodeTupleFuncUint64(8),
+				abi.EncodeTupleFuncBytes([]byte("inner")),
+			),
+			abi.EncodeTupleFuncBytes([]byte("outer")),
+		)
+		require.NoError(t, err)
+		require.Equal(t, nestedDynamicTuple, encoded)
+
+		var outerInt, innerInt uint64
+		var outerBytes, innerBytes []byte
+		err = abi.DecodeTuple(encoded,
+			abi.DecodeTupleFuncUint64(&outerInt),
+			abi.DecodeTupleFuncTuple(
+				abi.DecodeTupleFuncUint64(&innerInt),
+				abi.DecodeTupleFuncBytes(&innerBytes),
+			),
+			abi.DecodeTupleFuncBytes(&outerBytes),
+		)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, uint64(7), outerInt)
+		assert.Equal(t, uint64(8), innerInt)
+		assert.Equal(t, []byte("inner"), innerBytes)
+		assert.Equal(t, []byte("outer"), outerBytes)
+	})
+
+	t.Run("doubly nested dynamic tuples after a dynamic element", func(t *testing.T) {
+		// given
+		// the inner tuples start deep in the tail, behind the outer bytes,
+		// so their offsets only resolve against their own start
+		inner := abi.TupleType(abi.UintType(64), abi.BytesType())
+		schema := []abi.Type{
+			abi.BytesType(),
+			abi.TupleType(abi.BytesType(), inner),
+			abi.UintType(64),
+		}
+		want, err := abi.Encode(schema, []any{
+			[]byte("first outer bytes, longer than one word"),
+			[]any{[]byte("middle"), []any{uint64(9), []byte("innermost")}},
+			uint64(7),
+		})
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			Bytes([]byte("first outer bytes, longer than one word")).
+			Tuple(func(e *abi.TupleEncoder) {
+				e.Bytes([]byte("middle")).
+					Tuple(func(e *abi.TupleEncoder) { e.Uint64(9).Bytes([]byte("innermost")) })
+			}).
+			Uint64(7).
+			Encode()
+		require.NoError(t, err)
+
+		var outerBytes, middleBytes, innerBytes []byte
+		var innerInt, outerInt uint64
+		err = abi.NewTupleDecoder().
+			Bytes(&outerBytes).
+			Tuple(func(d *abi.TupleDecoder) {
+				d.Bytes(&middleBytes).
+					Tuple(func(d *abi.TupleDecoder) { d.Uint64(&innerInt).Bytes(&innerBytes) })
+			}).
+			Uint64(&outerInt).
+			Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, []byte("first outer bytes, longer than one word"), outerBytes)
+		assert.Equal(t, []byte("middle"), middleBytes)
+		assert.Equal(t, uint64(9), innerInt)
+		assert.Equal(t, []byte("innermost"), innerBytes)
+		assert.Equal(t, uint64(7), outerInt)
+	})
+
+	t.Run("static nested tuple", func(t *testing.T) {
+		// given
+		// a static nested tuple is stored inline and so its encoding is
+		// the same as if its elements were elements of the outer tuple
+		want, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncUint64(1),
+			abi.EncodeTupleFuncUint64(2),
+			abi.EncodeTupleFuncUint64(3),
+			abi.EncodeTupleFuncBytes([]byte("outer")),
+		)
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncUint64(1),
+			abi.EncodeTupleFuncTuple(
+				abi.EncodeTupleFuncUint64(2),
+				abi.EncodeTupleFuncUint64(3),
+			),
+			abi.EncodeTupleFuncBytes([]byte("outer")),
+		)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+	})
+}
+
+func TestDecodeTupleFuncTuple(t *testing.T) {
+	t.Run("offset not valid", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(32)
+		input[0] = 1
+		f := abi.DecodeTupleFuncTuple(abi.DecodeTupleFuncUint64(nil))
+		// when
+		err := f(input[0:32], input)
+		// then
+		assert.ErrorContains(t, err, "decoding offset")
+	})
+
+	t.Run("offset out of bounds", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(64)
+		f := abi.DecodeTupleFuncTuple(abi.DecodeTupleFuncUint64(nil))
+		// when
+		err := f(input[0:32], input)
+		// then
+		assert.ErrorContains(t, err, "offset out of bounds")
+	})
+
+	t.Run("nested tuple is invalid", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(32)
+		f := abi.DecodeTupleFuncTuple(abi.DecodeTupleFuncUint64(nil))
+		// when
+		err := f(input[0:32], input)
+		// then
+		assert.ErrorContains(t, err, "decoding tuple")
+	})
+}
+
+func TestEncodeDecodeWrappedTupleRoundTrip(t *testing.T) {
+	// given
+	// a function returning a single dynamic struct encodes it as the only
+	// element of the tuple of return values
+	schema := []abi.Type{abi.TupleType(abi.UintType(64), abi.BytesType())}
+	want, err := abi.Encode(schema, []any{[]any{uint64(7), []byte("struct")}})
+	require.NoError(t, err)
+
+	// when
+	encoded, err := abi.EncodeWrappedTuple(
+		abi.EncodeTupleFuncUint64(7),
+		abi.EncodeTupleFuncBytes([]byte("struct")),
+	)
+	require.NoError(t, err)
+	require.Equal(t, want, encoded)
+
+	var gotInt uint64
+	var gotBytes []byte
+	err = abi.DecodeWrappedTuple(encoded,
+		abi.DecodeTupleFuncUint64(&gotInt),
+		abi.DecodeTupleFuncBytes(&gotBytes),
+	)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, uint64(7), gotInt)
+	assert.Equal(t, []byte("struct"), gotBytes)
+}
+
+func TestDecoders_EmptyInput(t *testing.T) {
+	var u uint64
+	noop := func(int, *abi.TupleDecoder) error { return nil }
+
+	tests := []struct {
+		name   string
+		decode func(empty []byte) error
+	}{
+		{"DecodeUint64", func(e []byte) error {
+			_, err := abi.DecodeUint64(e)
+			return err
+		}},
+		{"DecodeUint64Bounded", func(e []byte) error {
+			_, err := abi.DecodeUint64Bounded(e, math.MaxUint64)
+			return err
+		}},
+		{"DecodeUint8", func(e []byte) error {
+			_, err := abi.DecodeUint8(e)
+			return err
+		}},
+		{"DecodeUint16", func(e []byte) error {
+			_, err := abi.DecodeUint16(e)
+			return err
+		}},
+		{"DecodeUint32", func(e []byte) error {
+			_, err := abi.DecodeUint32(e)
+			return err
+		}},
+		{"DecodeInteger", func(e []byte) error {
+			_, err := abi.DecodeInteger[int32](e)
+			return err
+		}},
+		{"DecodeInt256", func(e []byte) error {
+			_, err := abi.DecodeInt256(e)
+			return err
+		}},
+		{"DecodeBool", func(e []byte) error {
+			_, err := abi.DecodeBool(e)
+			return err
+		}},
+		{"DecodeSliceOfBool", func(e []byte) error {
+			_, err := abi.DecodeSliceOfBool(e)
+			return err
+		}},
+		{"DecodeFixedBytes", func(e []byte) error {
+			_, err := abi.DecodeFixedBytes(e, 4)
+			return err
+		}},
+		{"DecodeAddress", func(e []byte) error {
+			_, err := abi.DecodeAddress(e)
+			return err
+		}},
+		{"DecodeBytes", func(e []byte) error {
+			_, err := abi.DecodeBytes(e)
+			return err
+		}},
+		{"DecodeBytesWithOptions", func(e []byte) error {
+			_, err := abi.DecodeBytesWithOptions(e, abi.DecodeOptions{})
+			return err
+		}},
+		{"ValidateBytesEncoding", abi.ValidateBytesEncoding},
+		{"ValidateSliceOfBytesEncoding", abi.ValidateSliceOfBytesEncoding},
+		{"DecodeSliceOfBytesStrict", func(e []byte) error {
+			_, err := abi.DecodeSliceOfBytesStrict(e)
+			return err
+		}},
+		{"RangeSliceOfBytes", func(e []byte) error {
+			return abi.RangeSliceOfBytes(e, func(int, []byte) bool { return true })
+		}},
+		{"DecodeBytesNoCopy", func(e []byte) error {
+			_, err := abi.DecodeBytesNoCopy(e)
+			return err
+		}},
+		{"DecodeBytesInto", func(e []byte) error {
+			_, err := abi.DecodeBytesInto(e, make([]byte, 32))
+			return err
+		}},
+		{"DecodeBytesN", func(e []byte) error {
+			_, _, err := abi.DecodeBytesN(e)
+			return err
+		}},
+		{"DecodeBytesLE", func(e []byte) error {
+			_, err := abi.DecodeBytesLE(e)
+			return err
+		}},
+		{"DecodeBytesTo", func(e []byte) error {
+			_, err := abi.DecodeBytesTo(&bytes.Buffer{}, e)
+			return err
+		}},
+		{"DecodeSliceOfBytes", func(e []byte) error {
+			_, err := abi.DecodeSliceOfBytes(e)
+			return err
+		}},
+		{"DecodeSliceOfBytesWithOptions", func(e []byte) error {
+			_, err := abi.DecodeSliceOfBytesWithOptions(e, abi.DecodeOptions{})
+			return err
+		}},
+		{"DecodeSliceOfStrings", func(e []byte) error {
+			_, err := abi.DecodeSliceOfStrings(e)
+			return err
+		}},
+		{"DecodeSliceOfUint64", func(e []byte) error {
+			_, err := abi.DecodeSliceOfUint64(e)
+			return err
+		}},
+		{"DecodeSlice", func(e []byte) error {
+			_, err := abi.DecodeSlice(e, func(int) abi.DecoderFunc { return nil })
+			return err
+		}},
+		{"DecodeSliceCtx", func(e []byte) error {
+			ctx := context.Background()
+			_, err := abi.DecodeSliceCtx(ctx, e, func(int) abi.DecoderFunc { return nil })
+			return err
+		}},
+		{"DecodeSliceOfBytesParallel", func(e []byte) error {
+			_, err := abi.DecodeSliceOfBytesParallel(e, 2)
+			return err
+		}},
+		{"DecodeSliceOfAddresses", func(e []byte) error {
+			_, err := abi.DecodeSliceOfAddresses(e)
+			return err
+		}},
+		{"DecodeReturnSliceOfAddresses", func(e []byte) error {
+			_, err := abi.DecodeReturnSliceOfAddresses(e)
+			return err
+		}},
+		{"DecodeFixedUint64ArrayInto", func(e []byte) error {
+			return abi.DecodeFixedUint64ArrayInto(e, make([]uint64, 2))
+		}},
+		{"DecodeFixedArray", func(e []byte) error {
+			return abi.DecodeFixedArray(e, 2, func(int) abi.DecoderFunc { return nil })
+		}},
+		{"DecodeFixedArrayOfBytes32", func(e []byte) error {
+			_, err := abi.DecodeFixedArrayOfBytes32(e, 2)
+			return err
+		}},
+		{"DecodeTuple", func(e []byte) error {
+			return abi.DecodeTuple(e, abi.DecodeTupleFuncUint64(&u))
+		}},
+		{"DecodeTupleCtx", func(e []byte) error {
+			ctx := context.Background()
+			return abi.DecodeTupleCtx(ctx, e, abi.DecodeTupleFuncUint64(&u))
+		}},
+		{"DecodeTupleStrict", func(e []byte) error {
+			return abi.DecodeTupleStrict(e, func(d *abi.TupleDecoder) { d.Uint64(&u) })
+		}},
+		{"DecodeTupleHex", func(e []byte) error {
+			return abi.DecodeTupleHex(string(e), abi.DecodeTupleFuncUint64(&u))
+		}},
+		{"DecodeWrappedTuple", func(e []byte) error {
+			return abi.DecodeWrappedTuple(e, abi.DecodeTupleFuncUint64(&u))
+		}},
+		{"DecodeToStruct", func(e []byte) error {
+			return abi.DecodeToStruct(e, func(d *abi.TupleDecoder) { d.Uint64(&u) })
+		}},
+		{"DecodeSliceOfTuplesFunc", func(e []byte) error {
+			return abi.DecodeSliceOfTuplesFunc(e, noop)
+		}},
+		{"DecodeSliceOfTuplesFuncCtx", func(e []byte) error {
+			return abi.DecodeSliceOfTuplesFuncCtx(context.Background(), e, noop)
+		}},
+		{"DecodeTupleAuto", func(e []byte) error {
+			_, _, err := abi.DecodeTupleAuto(e, 1)
+			return err
+		}},
+		{"Decode", func(e []byte) error {
+			_, err := abi.Decode(e, []abi.Type{abi.BoolType()}, abi.DecodeOptions{})
+			return err
+		}},
+		{"DecodeValue", func(e []byte) error {
+			_, err := abi.DecodeValue(e, abi.StringType(), abi.DecodeOptions{})
+			return err
+		}},
+		{"DecodeMapFromArrays", func(e []byte) error {
+			_, err := abi.DecodeMapFromArrays(e, abi.DecodeTupleFuncUint64, abi.DecodeTupleFuncUint64)
+			return err
+		}},
+		{"DecodeMulticall", func(e []byte) error {
+			_, err := abi.DecodeMulticall(e)
+			return err
+		}},
+		{"DecodeRevertReason", func(e []byte) error {
+			_, err := abi.DecodeRevertReason(e)
+			return err
+		}},
+		{"DecodePanicCode", func(e []byte) error {
+			_, err := abi.DecodePanicCode(e)
+			return err
+		}},
+		{"DecodeCall", func(e []byte) error {
+			_, _, err := abi.DecodeCall(e)
+			return err
+		}},
+		{"SplitCalldata", func(e []byte) error {
+			_, _, err := abi.SplitCalldata(e)
+			return err
+		}},
+		{"DecodeLog", func(e []byte) error {
+			topics := [][]byte{nZeros(32)}
+			body := []abi.DecoderFunc{abi.DecodeTupleFuncUint64(&u)}
+			return abi.DecodeLog(topics, e, nil, body)
+		}},
+		{"InspectTuple", func(e []byte) error {
+			_, err := abi.InspectTuple(e, []abi.Kind{abi.Static})
+			return err
+		}},
+		{"TryDecodeError", func(e []byte) error {
+			_, err := abi.TryDecodeError(e)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, empty := range [][]byte{nil, {}} {
+				// when
+				err := tt.decode(empty)
+
+				// then
+				assert.ErrorIs(t, err, abi.ErrEmptyInput)
+			}
+		})
+	}
+
+	t.Run("valid empty encodings", func(t *testing.T) {
+		// when
+		values, err := abi.Decode(nil, nil, abi.DecodeOptions{})
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, values)
+
+		// when
+		err = abi.DecodeFixedUint64ArrayInto(nil, nil)
+		// then
+		require.NoError(t, err)
+
+		// when
+		words, err := abi.DecodeFixedArrayOfBytes32(nil, 0)
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, words)
+	})
+}
+
+func TestNewEncoderResult(t *testing.T) {
+	t.Run("custom encoder", func(t *testing.T) {
+		// given
+		encodeBool := func(v bool) abi.EncoderFunc {
+			return func() (abi.EncoderResult, error) {
+				var n uint64
+				if v {
+					n = 1
+				}
+				return abi.NewEncoderResult(false, abi.EncodeUint64(n)), nil
+			}
+		}
+		encodeBytes := func(v []byte) abi.EncoderFunc {
+			return func() (abi.EncoderResult, error) {
+				data, err := abi.EncodeBytes(v)
+				return abi.NewEncoderResult(true, data), err
+			}
+		}
+
+		// when
+		got, err := abi.EncodeTuple(encodeBool(true), encodeBytes([]byte("abc")))
+		require.NoError(t, err)
+
+		// then
+		want, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncUint64(1),
+			abi.EncodeTupleFuncBytes([]byte("abc")),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("is dynamic", func(t *testing.T) {
+		// then
+		assert.True(t, abi.NewEncoderResult(true, nil).IsDynamic())
+		assert.False(t, abi.NewEncoderResult(false, nil).IsDynamic())
+	})
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Run("DecodeTuple", func(t *testing.T) {
+		// given
+		encoded, err := abi.NewTupleEncoder().
+			Uint64(1).
+			Uint64(2).
+			Bytes([]byte("abc")).
+			Encode()
+		require.NoError(t, err)
+		encoded[len(encoded)-1] = 1
+
+		// when
+		var a, b uint64
+		var c []byte
+		err = abi.NewTupleDecoder().Uint64(&a).Uint64(&b).Bytes(&c).Decode(encoded)
+
+		// then
+		var decodeErr *abi.DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, 2, decodeErr.ElementIndex)
+		assert.Equal(t, uint64(64), decodeErr.Offset)
+		assert.ErrorContains(t, decodeErr.Cause, "padding contains non-zero values")
+		assert.ErrorContains(t, err, "decoding element 2: decoding bytes: padding contains non-zero values")
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		// given
+		// the array takes up two head slots, so the bool is in the third
+		schema := []abi.Type{abi.ArrayType(abi.UintType(8), 2), abi.BoolType()}
+		encoded, err := abi.Encode(schema, []any{[]any{1, 2}, true})
+		require.NoError(t, err)
+		encoded[len(encoded)-1] = 2
+
+		// when
+		_, err = abi.Decode(encoded, schema, abi.DecodeOptions{})
+
+		// then
+		var decodeErr *abi.DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, 1, decodeErr.ElementIndex)
+		assert.Equal(t, uint64(64), decodeErr.Offset)
+		assert.EqualError(t, err, "decoding element 1: invalid bool value")
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		// given
+		schema := []abi.Type{abi.UintType(8), abi.SliceType(abi.BoolType())}
+		encoded, err := abi.Encode(schema, []any{1, []any{true, false}})
+		require.NoError(t, err)
+		encoded[len(encoded)-1] = 2
+
+		// when
+		_, err = abi.Decode(encoded, schema, abi.DecodeOptions{})
+
+		// then
+		// the outermost element is found first, the inner one is its cause
+		var outer, inner *abi.DecodeError
+		require.ErrorAs(t, err, &outer)
+		assert.Equal(t, 1, outer.ElementIndex)
+		require.ErrorAs(t, outer.Cause, &inner)
+		assert.Equal(t, 1, inner.ElementIndex)
+		assert.Equal(t, uint64(32), inner.Offset)
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	t.Run("runs the encoder once", func(t *testing.T) {
+		// given
+		calls := 0
+		f := abi.Memoize(func() (abi.EncoderResult, error) {
+			calls++
+			data, err := abi.EncodeBytes([]byte("abc"))
+			return abi.NewEncoderResult(true, data), err
+		})
+
+		// when
+		first, err := abi.EncodeTuple(f, abi.EncodeTupleFuncUint64(1))
+		require.NoError(t, err)
+		second, err := abi.EncodeTuple(f, abi.EncodeTupleFuncUint64(1))
+		require.NoError(t, err)
+
+		// then
+		want, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncBytes([]byte("abc")),
+			abi.EncodeTupleFuncUint64(1),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want, first)
+		assert.Equal(t, want, second)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("caches errors", func(t *testing.T) {
+		// given
+		calls := 0
+		f := abi.Memoize(func() (abi.EncoderResult, error) {
+			calls++
+			return abi.EncoderResult{}, fmt.Errorf("failure %d", calls)
+		})
+
+		// when
+		_, err1 := f()
+		_, err2 := f()
+
+		// then
+		assert.EqualError(t, err1, "failure 1")
+		assert.EqualError(t, err2, "failure 1")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		// given
+		var calls atomic.Int32
+		f := abi.Memoize(func() (abi.EncoderResult, error) {
+			calls.Add(1)
+			return abi.NewEncoderResult(false, abi.EncodeUint64(1)), nil
+		})
+
+		// when
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = f()
+			}()
+		}
+		wg.Wait()
+
+		// then
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestEncodedSize(t *testing.T) {
+	t.Run("matches the encoding", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			encoders []abi.EncoderFunc
+		}{
+			{
+				name:     "static",
+				encoders: []abi.EncoderFunc{abi.EncodeTupleFuncUint64(1), abi.EncodeTupleFuncBool(true)},
+			},
+			{
+				name: "dynamic",
+				encoders: []abi.EncoderFunc{
+					abi.EncodeTupleFuncUint64(1),
+					abi.EncodeTupleFuncBytes(bytes.Repeat([]byte{1}, 40)),
+					abi.EncodeTupleFuncString("abc"),
+				},
+			},
+			{
+				name: "multi-slot static and nested tuple",
+				encoders: []abi.EncoderFunc{
+					abi.EncodeTupleFuncFixedUint64Array([]uint64{1, 2, 3}),
+					abi.EncodeTupleFuncTuple(
+						abi.EncodeTupleFuncUint64(1),
+						abi.EncodeTupleFuncBytes([]byte("inner")),
+					),
+				},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				got, err := abi.EncodedSize(tc.encoders...)
+				require.NoError(t, err)
+
+				// then
+				encoded, err := abi.EncodeTuple(tc.encoders...)
+				require.NoError(t, err)
+				assert.Equal(t, len(encoded), got)
+			})
+		}
+	})
+
+	t.Run("runs the encoders", func(t *testing.T) {
+		// given
+		calls := 0
+		counting := func() (abi.EncoderResult, error) {
+			calls++
+			return abi.NewEncoderResult(false, abi.EncodeUint64(1)), nil
+		}
+		memoized := abi.Memoize(counting)
+
+		// when
+		_, err := abi.EncodedSize(counting, memoized)
+		require.NoError(t, err)
+		_, err = abi.EncodeTuple(counting, memoized)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("encoder error", func(t *testing.T) {
+		// when
+		_, err := abi.EncodedSize(abi.EncodeTupleFuncInt256(nil))
+		// then
+		assert.ErrorContains(t, err, "encoding")
+	})
+}
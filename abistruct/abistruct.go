@@ -0,0 +1,213 @@
+// Package abistruct encodes go structs to ABI tuples and decodes tuples
+// into structs, driven by struct tags.  It uses reflection, so it lives
+// apart from package abi to keep the core reflection-free; it is an opt-in
+// convenience for wide structs, where listing every field on a
+// TupleEncoder or TupleDecoder is tedious.
+//
+// The fields of a struct are the elements of the tuple, in declaration
+// order.  A field takes part when it has an abi tag naming its ABI type:
+//
+//	type Transfer struct {
+//		From  [20]byte `abi:"address"`
+//		To    [20]byte `abi:"address"`
+//		Value *big.Int `abi:"uint256"`
+//	}
+//
+// The supported tags and the go type of their fields are:
+//
+//	uint8    uint8
+//	uint16   uint16
+//	uint32   uint32
+//	uint64   uint64
+//	uint256  big.Int
+//	int256   big.Int
+//	bool     bool
+//	address  [20]byte
+//	bytes32  [32]byte
+//	bytes    []byte
+//	string   string
+//
+// A field may also be a pointer to the type, such as *big.Int, which is
+// dereferenced when encoding and allocated when nil when decoding.  Fields
+// without an abi tag, or tagged abi:"-", are skipped.  An unknown tag, a
+// field whose type does not match its tag or a tagged unexported field is
+// an error.
+package abistruct
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/blocky/abi"
+)
+
+// fieldTypes maps each supported tag to the go type of its fields.
+var fieldTypes = map[string]reflect.Type{
+	"uint8":   reflect.TypeFor[uint8](),
+	"uint16":  reflect.TypeFor[uint16](),
+	"uint32":  reflect.TypeFor[uint32](),
+	"uint64":  reflect.TypeFor[uint64](),
+	"uint256": reflect.TypeFor[big.Int](),
+	"int256":  reflect.TypeFor[big.Int](),
+	"bool":    reflect.TypeFor[bool](),
+	"address": reflect.TypeFor[[20]byte](),
+	"bytes32": reflect.TypeFor[[32]byte](),
+	"bytes":   reflect.TypeFor[[]byte](),
+	"string":  reflect.TypeFor[string](),
+}
+
+// field is a tagged field of a struct.
+type field struct {
+	name  string
+	tag   string
+	value reflect.Value
+}
+
+// fields returns the tagged fields of the struct s in declaration order.
+func fields(s reflect.Value) ([]field, error) {
+	out := []field{}
+	for i := range s.NumField() {
+		sf := s.Type().Field(i)
+		tag, ok := sf.Tag.Lookup("abi")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		want, ok := fieldTypes[tag]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("field %s: unknown abi tag %q", sf.Name, tag)
+		case !sf.IsExported():
+			return nil, fmt.Errorf("field %s: tagged field is unexported", sf.Name)
+		case sf.Type != want && sf.Type != reflect.PointerTo(want):
+			format := "field %s: abi tag %q requires %s, got %s"
+			return nil, fmt.Errorf(format, sf.Name, tag, want, sf.Type)
+		}
+		out = append(out, field{name: sf.Name, tag: tag, value: s.Field(i)})
+	}
+	return out, nil
+}
+
+// DecodeInto decodes a tuple into the tagged fields of the struct pointed
+// to by v.  Nil pointer fields are allocated.  It is equivalent to
+// registering each field on a TupleDecoder.
+func DecodeInto(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("v must be a non-nil pointer to a struct")
+	}
+
+	fs, err := fields(rv.Elem())
+	if err != nil {
+		return err
+	}
+
+	d := abi.NewTupleDecoderWithCapacity(len(fs))
+	for _, f := range fs {
+		target := f.value
+		if target.Kind() == reflect.Pointer {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+			target = target.Elem()
+		}
+		addField(d, f.tag, target.Addr().Interface())
+	}
+	return d.Decode(data)
+}
+
+// addField registers ptr, a pointer to a field with the given tag, on d.
+func addField(d *abi.TupleDecoder, tag string, ptr any) {
+	switch tag {
+	case "uint8":
+		d.Uint8(ptr.(*uint8))
+	case "uint16":
+		d.Uint16(ptr.(*uint16))
+	case "uint32":
+		d.Uint32(ptr.(*uint32))
+	case "uint64":
+		d.Uint64(ptr.(*uint64))
+	case "uint256":
+		d.Uint256(ptr.(*big.Int))
+	case "int256":
+		d.Int256(ptr.(*big.Int))
+	case "bool":
+		d.Bool(ptr.(*bool))
+	case "address":
+		d.Address(ptr.(*[20]byte))
+	case "bytes32":
+		d.Bytes32(ptr.(*[32]byte))
+	case "bytes":
+		d.Bytes(ptr.(*[]byte))
+	case "string":
+		d.String(ptr.(*string))
+	}
+}
+
+// EncodeFrom encodes the tagged fields of v, a struct or a pointer to a
+// struct, as a tuple.  It is the inverse operation of DecodeInto and is
+// equivalent to registering each field on a TupleEncoder.
+func EncodeFrom(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("v must be a struct or a non-nil pointer to a struct")
+	}
+
+	fs, err := fields(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	e := abi.NewTupleEncoderWithCapacity(len(fs))
+	for _, f := range fs {
+		value := f.value
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return nil, fmt.Errorf("field %s: nil pointer", f.name)
+			}
+			value = value.Elem()
+		}
+		addValue(e, f.tag, value)
+	}
+	return e.Encode()
+}
+
+// addValue registers value, a field with the given tag, on e.
+func addValue(e *abi.TupleEncoder, tag string, value reflect.Value) {
+	switch tag {
+	case "uint8":
+		e.Uint8(uint8(value.Uint()))
+	case "uint16":
+		e.Uint16(uint16(value.Uint()))
+	case "uint32":
+		e.Uint32(uint32(value.Uint()))
+	case "uint64":
+		e.Uint64(value.Uint())
+	case "uint256":
+		e.Uint256(bigInt(value))
+	case "int256":
+		e.Int256(bigInt(value))
+	case "bool":
+		e.Bool(value.Bool())
+	case "address":
+		e.Address(value.Interface().([20]byte))
+	case "bytes32":
+		e.Bytes32(value.Interface().([32]byte))
+	case "bytes":
+		e.Bytes(value.Bytes())
+	case "string":
+		e.String(value.String())
+	}
+}
+
+// bigInt returns a copy of value, a big.Int, that is safe to use even
+// when value is not addressable.
+func bigInt(value reflect.Value) *big.Int {
+	n := value.Interface().(big.Int)
+	return new(big.Int).Set(&n)
+}
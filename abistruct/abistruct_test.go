@@ -0,0 +1,247 @@
+package abistruct_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+	"github.com/blocky/abi/abistruct"
+)
+
+type transfer struct {
+	From  [20]byte `abi:"address"`
+	To    [20]byte `abi:"address"`
+	Value *big.Int `abi:"uint256"`
+}
+
+// everything has a field of each supported tag.
+type everything struct {
+	U8      uint8    `abi:"uint8"`
+	U16     uint16   `abi:"uint16"`
+	U32     uint32   `abi:"uint32"`
+	U64     *uint64  `abi:"uint64"`
+	U256    big.Int  `abi:"uint256"`
+	I256    *big.Int `abi:"int256"`
+	Flag    bool     `abi:"bool"`
+	Addr    [20]byte `abi:"address"`
+	Hash    [32]byte `abi:"bytes32"`
+	Data    []byte   `abi:"bytes"`
+	Name    string   `abi:"string"`
+	Ignored string
+	Skipped string `abi:"-"`
+}
+
+func TestDecodeInto(t *testing.T) {
+	from, to := [20]byte{0x01}, [20]byte{0x02}
+
+	t.Run("transfer", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.AddressType(), abi.AddressType(), abi.UintType(256)}
+		data, err := abi.Encode(types, []any{from, to, big.NewInt(1000)})
+		require.NoError(t, err)
+		// when
+		var got transfer
+		err = abistruct.DecodeInto(data, &got)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, transfer{From: from, To: to, Value: big.NewInt(1000)}, got)
+	})
+
+	t.Run("every tag", func(t *testing.T) {
+		// given
+		var types []abi.Type
+		for _, typ := range []string{
+			"uint8", "uint16", "uint32", "uint64", "uint256", "int256",
+			"bool", "address", "bytes32", "bytes", "string",
+		} {
+			types = append(types, abi.MustParseType(typ))
+		}
+		data, err := abi.Encode(types, []any{
+			8, 16, 32, 64, 256, -256,
+			true, from, make([]byte, 32), []byte("data"), "name",
+		})
+		require.NoError(t, err)
+		got := everything{Ignored: "kept", Skipped: "kept"}
+		// when
+		err = abistruct.DecodeInto(data, &got)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint8(8), got.U8)
+		assert.Equal(t, uint16(16), got.U16)
+		assert.Equal(t, uint32(32), got.U32)
+		require.NotNil(t, got.U64)
+		assert.Equal(t, uint64(64), *got.U64)
+		assert.Equal(t, int64(256), got.U256.Int64())
+		assert.Equal(t, int64(-256), got.I256.Int64())
+		assert.True(t, got.Flag)
+		assert.Equal(t, from, got.Addr)
+		assert.Equal(t, [32]byte{}, got.Hash)
+		assert.Equal(t, []byte("data"), got.Data)
+		assert.Equal(t, "name", got.Name)
+		assert.Equal(t, "kept", got.Ignored)
+		assert.Equal(t, "kept", got.Skipped)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		data := abi.EncodeUint64(300)
+
+		for _, tc := range []struct {
+			name    string
+			v       any
+			wantErr string
+		}{
+			{
+				name:    "not a pointer",
+				v:       transfer{},
+				wantErr: "v must be a non-nil pointer to a struct",
+			},
+			{
+				name:    "nil pointer",
+				v:       (*transfer)(nil),
+				wantErr: "v must be a non-nil pointer to a struct",
+			},
+			{
+				name:    "pointer to non-struct",
+				v:       new(int),
+				wantErr: "v must be a non-nil pointer to a struct",
+			},
+			{
+				name: "unknown tag",
+				v: &struct {
+					V uint64 `abi:"uint"`
+				}{},
+				wantErr: `field V: unknown abi tag "uint"`,
+			},
+			{
+				name: "mismatched type",
+				v: &struct {
+					V uint32 `abi:"uint64"`
+				}{},
+				wantErr: `field V: abi tag "uint64" requires uint64, got uint32`,
+			},
+			{
+				name: "unexported",
+				v: &struct {
+					v uint64 `abi:"uint64"`
+				}{},
+				wantErr: "field v: tagged field is unexported",
+			},
+			{
+				name: "out of range",
+				v: &struct {
+					V uint8 `abi:"uint8"`
+				}{},
+				wantErr: "out of range for uint8",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				err := abistruct.DecodeInto(data, tc.v)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+type payment struct {
+	Amount *big.Int `abi:"uint256"`
+	Memo   []byte   `abi:"bytes"`
+	Payee  [20]byte `abi:"address"`
+	Note   string
+}
+
+func TestEncodeFrom(t *testing.T) {
+	payee := [20]byte{0x03}
+
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		want := payment{Amount: big.NewInt(1234), Memo: []byte("memo"), Payee: payee}
+		// when
+		data, err := abistruct.EncodeFrom(want)
+		require.NoError(t, err)
+		var got payment
+		err = abistruct.DecodeInto(data, &got)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("field order defines tuple order", func(t *testing.T) {
+		// given
+		v := &payment{Amount: big.NewInt(1234), Memo: []byte("memo"), Payee: payee}
+		types := []abi.Type{abi.UintType(256), abi.BytesType(), abi.AddressType()}
+		want, err := abi.Encode(types, []any{big.NewInt(1234), []byte("memo"), payee})
+		require.NoError(t, err)
+		// when
+		got, err := abistruct.EncodeFrom(v)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("every tag", func(t *testing.T) {
+		// given
+		u64 := uint64(64)
+		want := everything{
+			U8: 8, U16: 16, U32: 32, U64: &u64,
+			U256: *big.NewInt(256), I256: big.NewInt(-256),
+			Flag: true, Addr: payee, Hash: [32]byte{0x04},
+			Data: []byte("data"), Name: "name",
+		}
+		// when
+		data, err := abistruct.EncodeFrom(want)
+		require.NoError(t, err)
+		var got everything
+		err = abistruct.DecodeInto(data, &got)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			v       any
+			wantErr string
+		}{
+			{
+				name:    "not a struct",
+				v:       42,
+				wantErr: "v must be a struct or a non-nil pointer to a struct",
+			},
+			{
+				name:    "nil pointer",
+				v:       (*payment)(nil),
+				wantErr: "v must be a struct or a non-nil pointer to a struct",
+			},
+			{
+				name:    "nil pointer field",
+				v:       payment{},
+				wantErr: "field Amount: nil pointer",
+			},
+			{
+				name: "unexported",
+				v: struct {
+					amount uint64 `abi:"uint64"`
+				}{},
+				wantErr: "field amount: tagged field is unexported",
+			},
+			{
+				name:    "out of range",
+				v:       payment{Amount: big.NewInt(-1)},
+				wantErr: "value out of range for uint256",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				_, err := abistruct.EncodeFrom(tc.v)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
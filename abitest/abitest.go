@@ -0,0 +1,76 @@
+// Package abitest provides helpers for testing code that produces ABI
+// encoded data.
+package abitest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blocky/abi"
+)
+
+// AssertCall checks that calldata is a call of the function with signature
+// sig, such as "transfer(address,uint256)", with the arguments wantArgs.
+// The selector of calldata is checked against sig and the arguments are
+// decoded by the types of sig and compared to wantArgs.  Arguments are
+// compared by their encoding, so wantArgs may be given as any go value
+// that Encode accepts for the type, for example, an int for a uint256.
+// Mismatches are reported through t, naming the selector or the index of
+// the argument that diverged.  It returns whether calldata matched.
+func AssertCall(t testing.TB, calldata []byte, sig string, wantArgs ...any) bool {
+	t.Helper()
+
+	name, types, err := abi.ParseSignature(sig)
+	if err != nil {
+		t.Errorf("parsing signature %q: %v", sig, err)
+		return false
+	}
+	if len(types) != len(wantArgs) {
+		t.Errorf("signature has %d arguments but got %d", len(types), len(wantArgs))
+		return false
+	}
+
+	selector, args, err := abi.SplitCalldata(calldata)
+	if err != nil {
+		t.Errorf("splitting calldata: %v", err)
+		return false
+	}
+
+	names := make([]string, len(types))
+	for i := range types {
+		names[i] = types[i].String()
+	}
+	canonical := name + "(" + strings.Join(names, ",") + ")"
+	if want := abi.MethodID(canonical); selector != want {
+		t.Errorf("selector mismatch: got 0x%x want 0x%x (%s)", selector, want, canonical)
+		return false
+	}
+
+	got, err := abi.Decode(args, types, abi.DecodeOptions{})
+	if err != nil {
+		t.Errorf("decoding arguments: %v", err)
+		return false
+	}
+
+	ok := true
+	for i := range types {
+		gotEncoded, err := abi.EncodeValue(types[i], got[i])
+		if err != nil {
+			t.Errorf("argument %d: encoding got: %v", i, err)
+			ok = false
+			continue
+		}
+		wantEncoded, err := abi.EncodeValue(types[i], wantArgs[i])
+		if err != nil {
+			t.Errorf("argument %d: encoding want: %v", i, err)
+			ok = false
+			continue
+		}
+		if !bytes.Equal(gotEncoded, wantEncoded) {
+			t.Errorf("argument %d (%s) mismatch: got %v want %v", i, types[i], got[i], wantArgs[i])
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,102 @@
+package abitest_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+	"github.com/blocky/abi/abitest"
+)
+
+// recorder is a testing.TB that records reported errors rather than
+// failing the test.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func transferCalldata(t *testing.T, to [20]byte, amount *big.Int) []byte {
+	types := []abi.Type{abi.AddressType(), abi.UintType(256)}
+	args, err := abi.Encode(types, []any{to, amount})
+	require.NoError(t, err)
+	return append(abi.TransferSelector[:], args...)
+}
+
+func TestAssertCall(t *testing.T) {
+	to := [20]byte{0x11, 0x22}
+	calldata := transferCalldata(t, to, big.NewInt(1000))
+
+	t.Run("matching call", func(t *testing.T) {
+		// given
+		r := &recorder{}
+
+		// when
+		ok := abitest.AssertCall(r, calldata, "transfer(address to, uint256 amount)", to, 1000)
+
+		// then
+		assert.True(t, ok)
+		assert.Empty(t, r.errs)
+	})
+
+	t.Run("selector mismatch", func(t *testing.T) {
+		// given
+		r := &recorder{}
+
+		// when
+		ok := abitest.AssertCall(r, calldata, "approve(address,uint256)", to, 1000)
+
+		// then
+		assert.False(t, ok)
+		require.Len(t, r.errs, 1)
+		assert.Contains(t, r.errs[0], "selector mismatch")
+	})
+
+	t.Run("argument mismatch", func(t *testing.T) {
+		// given
+		r := &recorder{}
+
+		// when
+		ok := abitest.AssertCall(r, calldata, "transfer(address,uint256)", to, 999)
+
+		// then
+		assert.False(t, ok)
+		require.Len(t, r.errs, 1)
+		assert.Contains(t, r.errs[0], "argument 1 (uint256) mismatch")
+	})
+
+	t.Run("argument count mismatch", func(t *testing.T) {
+		// given
+		r := &recorder{}
+
+		// when
+		ok := abitest.AssertCall(r, calldata, "transfer(address,uint256)", to)
+
+		// then
+		assert.False(t, ok)
+		require.Len(t, r.errs, 1)
+		assert.Contains(t, r.errs[0], "signature has 2 arguments but got 1")
+	})
+
+	t.Run("truncated calldata", func(t *testing.T) {
+		// given
+		r := &recorder{}
+
+		// when
+		ok := abitest.AssertCall(r, calldata[:40], "transfer(address,uint256)", to, 1000)
+
+		// then
+		assert.False(t, ok)
+		require.Len(t, r.errs, 1)
+		assert.Contains(t, r.errs[0], "decoding arguments")
+	})
+}
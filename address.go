@@ -0,0 +1,143 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ZeroAddress is the address with all bytes set to zero.
+var ZeroAddress [20]byte
+
+// IsZeroAddress reports whether addr is the zero address.
+func IsZeroAddress(addr [20]byte) bool {
+	return addr == ZeroAddress
+}
+
+// AddressEqual reports whether a and b are the same address.  Addresses
+// should be compared directly rather than through their 32-byte encodings.
+func AddressEqual(a, b [20]byte) bool {
+	return a == b
+}
+
+// EncodeZeroAddress returns the 32-byte ABI encoding of the zero address.
+func EncodeZeroAddress() []byte {
+	return EncodeAddress(ZeroAddress)
+}
+
+// EncodeAddress encodes a 20-byte address to 32-byte ABI format by padding
+// it on the left with zeros.  It is the inverse operation of DecodeAddress.
+func EncodeAddress(addr [20]byte) []byte {
+	// an address always fits in a word, so padding cannot fail
+	out, _ := padLeft(addr[:], 32)
+	return out
+}
+
+// DecodeAddress decodes ABI bytes back to a 20-byte address. It is the
+// inverse operation of EncodeAddress.
+func DecodeAddress(v []byte) ([20]byte, error) {
+	var addr [20]byte
+	switch {
+	case len(v) == 0:
+		return addr, ErrEmptyInput
+	case len(v) != 32:
+		return addr, errors.New("address encoding must contain 32 bytes")
+	}
+
+	padding, data := v[:12], v[12:]
+	if err := checkPadding(padding); err != nil {
+		return addr, err
+	}
+
+	copy(addr[:], data)
+	return addr, nil
+}
+
+// EncodeTupleFuncAddress encodes an address as the k-th element of a tuple.
+func EncodeTupleFuncAddress(addr [20]byte) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data := EncodeAddress(addr)
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncAddress decodes an address as the k-th element of a tuple.
+func DecodeTupleFuncAddress(v *[20]byte) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := DecodeAddress(cur)
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		*v = vv
+		return nil
+	}
+}
+
+// EncodeSliceOfAddresses encodes a slice of addresses to an address[].
+// Addresses are static, so they are stored inline after the element count.
+// It is the inverse operation of DecodeSliceOfAddresses.
+func EncodeSliceOfAddresses(v [][20]byte) []byte {
+	out := make([]byte, 0, 64+32*len(v))
+	out = append(out, precomputedSliceHeader...)
+	out = append(out, EncodeUint64(uint64(len(v)))...)
+	for i := range v {
+		out = append(out, EncodeAddress(v[i])...)
+	}
+	return out
+}
+
+// DecodeSliceOfAddresses decodes a slice of addresses from an address[].
+// It is the inverse operation of EncodeSliceOfAddresses.
+func DecodeSliceOfAddresses(abiEncoded []byte) ([][20]byte, error) {
+	switch {
+	case len(abiEncoded) == 0:
+		return nil, ErrEmptyInput
+	case len(abiEncoded) < 32:
+		return nil, errors.New("not long enough to have a head")
+	case !sliceEqual(abiEncoded[:32], precomputedSliceHeader):
+		return nil, errors.New("not a slice type")
+	}
+
+	return decodeAddresses(abiEncoded[32:])
+}
+
+// DecodeReturnSliceOfAddresses decodes the return data of a function that
+// returns a single address[], such as getOwners(), by following the offset
+// word at the start of the data to the element count.  For return data
+// produced by solidity the offset is always 0x20, in which case this is
+// equivalent to DecodeSliceOfAddresses.
+func DecodeReturnSliceOfAddresses(data []byte) ([][20]byte, error) {
+	switch {
+	case len(data) == 0:
+		return nil, ErrEmptyInput
+	case len(data) < 32:
+		return nil, errors.New("not long enough to have a head")
+	}
+
+	offset, err := DecodeUint64(data[:32])
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding offset, %w", err)
+	case offset < 32 || offset%32 != 0 || offset > uint64(len(data)):
+		return nil, fmt.Errorf("invalid offset %d", offset)
+	}
+
+	return decodeAddresses(data[offset:])
+}
+
+// decodeAddresses decodes the element count and addresses of an address[].
+func decodeAddresses(body []byte) ([][20]byte, error) {
+	words, err := splitSliceOfStatic(body, &DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([][20]byte, len(words))
+	for i := range words {
+		addrs[i], err = DecodeAddress(words[i])
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, err)
+		}
+	}
+	return addrs, nil
+}
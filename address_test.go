@@ -0,0 +1,335 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func someAddress() [20]byte {
+	var addr [20]byte
+	for i := range addr {
+		addr[i] = byte(i + 1)
+	}
+	return addr
+}
+
+func TestEncodeAddress(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := someAddress()
+		want := append(nZeros(12), input[:]...)
+		// when
+		got := abi.EncodeAddress(input)
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDecodeAddress(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := someAddress()
+		input := append(nZeros(12), want[:]...)
+		// when
+		got, err := abi.DecodeAddress(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("not 32 bytes", func(t *testing.T) {
+		// given
+		addr := someAddress()
+		input := addr[:]
+		// when
+		_, err := abi.DecodeAddress(input)
+		// then
+		assert.ErrorContains(t, err, "must contain 32 bytes")
+	})
+
+	t.Run("bad padding", func(t *testing.T) {
+		// given
+		input := abi.EncodeAddress(someAddress())
+		input[11] = 1
+		// when
+		_, err := abi.DecodeAddress(input)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
+
+func TestEncodeDecodeAddressRoundTrip(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := someAddress()
+		// when
+		data := abi.EncodeAddress(input)
+		got, err := abi.DecodeAddress(data)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, input, got)
+	})
+}
+
+func TestIsZeroAddress(t *testing.T) {
+	// when
+	zero := abi.IsZeroAddress(abi.ZeroAddress)
+	nonZero := abi.IsZeroAddress(someAddress())
+	// then
+	assert.True(t, zero)
+	assert.False(t, nonZero)
+}
+
+func TestAddressEqual(t *testing.T) {
+	// when
+	same := abi.AddressEqual(someAddress(), someAddress())
+	different := abi.AddressEqual(someAddress(), abi.ZeroAddress)
+	// then
+	assert.True(t, same)
+	assert.False(t, different)
+}
+
+func TestEncodeZeroAddress(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got := abi.EncodeZeroAddress()
+		// then
+		assert.Equal(t, nZeros(32), got)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeAddress(abi.EncodeZeroAddress())
+		require.NoError(t, err)
+		// then
+		assert.True(t, abi.IsZeroAddress(got))
+		assert.Equal(t, abi.EncodeAddress(abi.ZeroAddress), abi.EncodeZeroAddress())
+	})
+}
+
+// getOwners is the return data of a getOwners() view function returning
+// an address[] of three owners, as produced by go-ethereum.
+var getOwners = struct {
+	native  [][20]byte
+	encoded []byte
+}{
+	native: [][20]byte{
+		hexAddress("742d35cc6634c0532925a3b844bc454e4438f44e"),
+		hexAddress("c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"),
+		hexAddress("a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+	},
+	encoded: hexDecode("" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"000000000000000000000000742d35cc6634c0532925a3b844bc454e4438f44e" +
+		"000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" +
+		"000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+	),
+}
+
+func TestEncodeSliceOfAddresses(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got := abi.EncodeSliceOfAddresses(getOwners.native)
+		// then
+		assert.Equal(t, getOwners.encoded, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// when
+		got := abi.EncodeSliceOfAddresses(nil)
+		// then
+		assert.Equal(t, append(abi.SliceHeader(), nZeros(32)...), got)
+	})
+}
+
+func TestEncodeDecodeSliceOfAddressesRoundTrip(t *testing.T) {
+	for _, n := range []int{0, 1, 10} {
+		t.Run(fmt.Sprintf("%d addresses", n), func(t *testing.T) {
+			// given
+			addrs := make([][20]byte, n)
+			elems := make([]any, n)
+			for i := range addrs {
+				addrs[i] = [20]byte{0: byte(i + 1), 19: byte(i + 1)}
+				elems[i] = addrs[i]
+			}
+			want, err := abi.EncodeValue(abi.SliceType(abi.AddressType()), elems)
+			require.NoError(t, err)
+
+			// when
+			encoded := abi.EncodeSliceOfAddresses(addrs)
+			got, err := abi.DecodeSliceOfAddresses(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, want, encoded)
+			assert.Equal(t, addrs, got)
+			assert.Len(t, encoded, 64+32*n)
+		})
+	}
+}
+
+func TestDecodeSliceOfAddresses(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeSliceOfAddresses(getOwners.encoded)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, getOwners.native, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// given
+		input := append(abi.SliceHeader(), abi.EncodeUint64(0)...)
+		// when
+		got, err := abi.DecodeSliceOfAddresses(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, [][20]byte{}, got)
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		input := append([]byte{}, getOwners.encoded...)
+		input[31] = 0x40
+		// when
+		_, err := abi.DecodeSliceOfAddresses(input)
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("too few elements", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeSliceOfAddresses(getOwners.encoded[:len(getOwners.encoded)-32])
+		// then
+		assert.ErrorContains(t, err, "slice of 3 elements must contain 96 bytes")
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		// given
+		input := append([]byte{}, getOwners.encoded...)
+		input[64+32] = 1
+		// when
+		_, err := abi.DecodeSliceOfAddresses(input)
+		// then
+		assert.ErrorContains(t, err, "decoding element 1")
+	})
+}
+
+func TestDecodeReturnSliceOfAddresses(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeReturnSliceOfAddresses(getOwners.encoded)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, getOwners.native, got)
+	})
+
+	t.Run("follows the offset", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(64)
+		input = append(input, nZeros(32)...)
+		input = append(input, getOwners.encoded[32:]...)
+		// when
+		got, err := abi.DecodeReturnSliceOfAddresses(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, getOwners.native, got)
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		// given
+		input := append([]byte{}, getOwners.encoded...)
+		input[31] = 0x21
+		// when
+		_, err := abi.DecodeReturnSliceOfAddresses(input)
+		// then
+		assert.ErrorContains(t, err, "invalid offset 33")
+	})
+}
+
+func TestTupleEncoderDecoder_Address(t *testing.T) {
+	t.Run("matches multicall element", func(t *testing.T) {
+		// given
+		// the first call of twoCalls, which starts after the slice header,
+		// the element count and the two offsets
+		call := twoCalls.native[0]
+		want := twoCalls.encoded[4*32 : 8*32]
+
+		// when
+		got, err := abi.NewTupleEncoder().Address(call.Target).Bytes(call.Data).Encode()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		want := someAddress()
+		encoded, err := abi.NewTupleEncoder().Uint64(1).Address(want).Encode()
+		require.NoError(t, err)
+
+		// when
+		var got [20]byte
+		var u uint64
+		err = abi.NewTupleDecoder().Uint64(&u).Address(&got).Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, uint64(1), u)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		// given
+		// the tuple (address from, address to, uint256 value) of a Transfer
+		from := someAddress()
+		to := [20]byte{19: 0xff}
+		value, _ := new(big.Int).SetString("1000000000000000000000", 10)
+		input, err := abi.Encode(
+			[]abi.Type{abi.AddressType(), abi.AddressType(), abi.UintType(256)},
+			[]any{from, to, value},
+		)
+		require.NoError(t, err)
+
+		// when
+		var gotFrom, gotTo [20]byte
+		var gotValue big.Int
+		err = abi.NewTupleDecoder().
+			Address(&gotFrom).
+			Address(&gotTo).
+			Uint256(&gotValue).
+			Decode(input)
+		require.NoError(t, err)
+		encoded, err := abi.NewTupleEncoder().Address(from).Address(to).Uint256(value).Encode()
+		require.NoError(t, err)
+
+		// then
+		assert.Len(t, input, 3*32)
+		assert.Equal(t, from, gotFrom)
+		assert.Equal(t, to, gotTo)
+		assert.Equal(t, 0, value.Cmp(&gotValue))
+		assert.Equal(t, input, encoded)
+	})
+
+	t.Run("bad padding", func(t *testing.T) {
+		// given
+		encoded, err := abi.NewTupleEncoder().Address(someAddress()).Encode()
+		require.NoError(t, err)
+		encoded[0] = 1
+
+		// when
+		var got [20]byte
+		err = abi.NewTupleDecoder().Address(&got).Decode(encoded)
+
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
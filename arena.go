@@ -0,0 +1,77 @@
+package abi
+
+// Arena hands out byte slices from large chunks of memory, which are all
+// freed together by Reset.  Decoding the bytes of many tuples into an
+// arena, with DecodeTupleFuncBytesArena, replaces an allocation per
+// element with an occasional allocation of a chunk, which reduces the
+// pressure on the garbage collector.  An Arena is not safe for concurrent
+// use.  The zero value is an empty arena that is ready to use.
+type Arena struct {
+	buf []byte
+	off int
+}
+
+// minArenaChunk is the size of the first chunk of an arena that was not
+// given a size.
+const minArenaChunk = 4096
+
+// NewArena returns an arena whose first chunk holds size bytes.
+func NewArena(size int) *Arena {
+	return &Arena{buf: make([]byte, max(size, 0))}
+}
+
+// Alloc returns a zeroed slice of n bytes from the arena.  Its capacity is
+// limited to n, so appending to it does not overwrite other slices.  The
+// slice is valid until Reset is called.
+func (a *Arena) Alloc(n int) []byte {
+	if a.buf == nil || n > len(a.buf)-a.off {
+		// the current chunk stays alive as long as slices of it do
+		a.buf = make([]byte, max(2*len(a.buf), n, minArenaChunk))
+		a.off = 0
+	}
+
+	out := a.buf[a.off : a.off+n : a.off+n]
+	a.off += n
+	return out
+}
+
+// Reset frees all slices handed out by the arena, so that their memory is
+// reused by later calls to Alloc.  The slices must no longer be used.
+func (a *Arena) Reset() {
+	clear(a.buf[:a.off])
+	a.off = 0
+}
+
+// DecodeTupleFuncBytesArena decodes bytes as the k-th element of a tuple
+// like DecodeTupleFuncBytes, but copies them into a slice taken from arena
+// rather than into a new allocation.
+func DecodeTupleFuncBytesArena(v *[]byte, arena *Arena) DecoderFunc {
+	return func(cur, full []byte) error {
+		data, err := tupleBytesData(cur, full)
+		if err != nil {
+			return err
+		}
+
+		vv := arena.Alloc(len(data))
+		copy(vv, data)
+		*v = vv
+		return nil
+	}
+}
+
+// DecodeTupleWithArena decodes a tuple like DecodeTuple, where decoders
+// such as those of DecodeTupleFuncBytesArena take their memory from
+// arena.  If decoding fails, the memory that the decoders took since the
+// start of the call is given back to arena, so that failed decodes do not
+// use it up, and the bytes decoded before the failure must not be used.
+func DecodeTupleWithArena(data []byte, arena *Arena, decoders ...DecoderFunc) error {
+	buf, off := arena.buf, arena.off
+	err := DecodeTuple(data, decoders...)
+	if err != nil {
+		// everything past off was either unused or taken by the decoders,
+		// and any chunk allocated by the decoders is dropped
+		clear(buf[off:])
+		arena.buf, arena.off = buf, off
+	}
+	return err
+}
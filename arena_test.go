@@ -0,0 +1,150 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestArena(t *testing.T) {
+	t.Run("alloc", func(t *testing.T) {
+		// given
+		arena := abi.NewArena(64)
+		// when
+		a := arena.Alloc(10)
+		b := arena.Alloc(20)
+		// then
+		assert.Equal(t, make([]byte, 10), a)
+		assert.Equal(t, 10, cap(a))
+		assert.Equal(t, make([]byte, 20), b)
+		_ = append(a, 1)
+		assert.Equal(t, make([]byte, 20), b)
+	})
+
+	t.Run("grows past the first chunk", func(t *testing.T) {
+		// given
+		arena := abi.NewArena(8)
+		a := arena.Alloc(8)
+		copy(a, "abcdefgh")
+		// when
+		b := arena.Alloc(100)
+		// then
+		assert.Len(t, b, 100)
+		assert.Equal(t, []byte("abcdefgh"), a)
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		// given
+		var arena abi.Arena
+		// when
+		got := arena.Alloc(0)
+		// then
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+
+	t.Run("reset reuses zeroed memory", func(t *testing.T) {
+		// given
+		arena := abi.NewArena(64)
+		a := arena.Alloc(10)
+		copy(a, "0123456789")
+		// when
+		arena.Reset()
+		b := arena.Alloc(10)
+		// then
+		assert.Same(t, &a[0], &b[0])
+		assert.Equal(t, make([]byte, 10), b)
+	})
+}
+
+func TestDecodeTupleFuncBytesArena(t *testing.T) {
+	input, err := abi.NewTupleEncoder().
+		Bytes([]byte("hello")).
+		Uint64(7).
+		Bytes([]byte{}).
+		Encode()
+	require.NoError(t, err)
+
+	t.Run("matches DecodeTupleFuncBytes", func(t *testing.T) {
+		// given
+		arena := abi.NewArena(64)
+		var wantA, wantB, gotA, gotB []byte
+		var u uint64
+		err := abi.DecodeTuple(input,
+			abi.DecodeTupleFuncBytes(&wantA),
+			abi.DecodeTupleFuncUint64(&u),
+			abi.DecodeTupleFuncBytes(&wantB),
+		)
+		require.NoError(t, err)
+
+		// when
+		err = abi.DecodeTupleWithArena(input, arena,
+			abi.DecodeTupleFuncBytesArena(&gotA, arena),
+			abi.DecodeTupleFuncUint64(&u),
+			abi.DecodeTupleFuncBytesArena(&gotB, arena),
+		)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, wantA, gotA)
+		assert.Equal(t, wantB, gotB)
+	})
+
+	t.Run("same errors as DecodeTupleFuncBytes", func(t *testing.T) {
+		// given
+		invalid := append([]byte{}, input...)
+		invalid[len(invalid)-1] = 1
+		var b []byte
+		want := abi.DecodeTupleFuncBytes(&b)(invalid[64:96], invalid)
+		require.Error(t, want)
+		// when
+		err := abi.DecodeTupleFuncBytesArena(&b, abi.NewArena(64))(invalid[64:96], invalid)
+		// then
+		assert.Equal(t, want, err)
+	})
+
+	t.Run("does not allocate once the arena is warm", func(t *testing.T) {
+		// given
+		arena := abi.NewArena(64)
+		var a, b []byte
+		var u uint64
+		decoders := []abi.DecoderFunc{
+			abi.DecodeTupleFuncBytesArena(&a, arena),
+			abi.DecodeTupleFuncUint64(&u),
+			abi.DecodeTupleFuncBytesArena(&b, arena),
+		}
+		// when
+		allocs := testing.AllocsPerRun(10, func() {
+			arena.Reset()
+			_ = abi.DecodeTupleWithArena(input, arena, decoders...)
+		})
+		// then
+		assert.Zero(t, allocs)
+	})
+}
+
+func TestDecodeTupleWithArena(t *testing.T) {
+	t.Run("failure gives the memory back", func(t *testing.T) {
+		// given
+		// the first element decodes, but the second is out of bounds
+		input, err := abi.NewTupleEncoder().Bytes([]byte("hello")).Uint64(1 << 20).Encode()
+		require.NoError(t, err)
+		arena := abi.NewArena(64)
+		var a, b []byte
+
+		// when
+		err = abi.DecodeTupleWithArena(input, arena,
+			abi.DecodeTupleFuncBytesArena(&a, arena),
+			abi.DecodeTupleFuncBytesArena(&b, arena),
+		)
+
+		// then
+		assert.ErrorContains(t, err, "out of bounds")
+		after := arena.Alloc(5)
+		assert.Same(t, &a[0], &after[0])
+		assert.Equal(t, make([]byte, 5), after)
+	})
+}
@@ -0,0 +1,102 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DecodeTupleAuto decodes data as a tuple of numFields fields whose types
+// are not known in advance.  The type of each field is inferred from the
+// contents of its head slot and returned alongside the decoded values, so
+// that callers can check the guesses.  Inference is best-effort and may be
+// wrong for ambiguous slots:
+//
+//   - a slot holding a valid offset to a well formed bytes value is
+//     inferred as string when the value is printable utf-8 and as bytes
+//     otherwise
+//   - a slot holding a value between 2^128 and 2^160 is inferred as address
+//   - a slot holding a small negative two's complement value is inferred
+//     as int256
+//   - any other slot is inferred as uint256
+//
+// Values are returned with the go types documented in Decode.
+func DecodeTupleAuto(data []byte, numFields int) ([]any, []Type, error) {
+	switch {
+	case numFields < 1:
+		return nil, nil, fmt.Errorf("invalid field count %d", numFields)
+	case len(data) == 0:
+		return nil, nil, ErrEmptyInput
+	case len(data) < 32*numFields:
+		return nil, nil, errors.New("not long enough to support all fields")
+	}
+
+	types := make([]Type, numFields)
+	for i := range types {
+		types[i] = inferType(data[i*32:(i+1)*32], data, numFields)
+	}
+
+	values, err := Decode(data, types, DecodeOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, types, nil
+}
+
+// inferType guesses the type of the value whose head slot is word within
+// a tuple of numFields fields encoded in full.
+func inferType(word, full []byte, numFields int) Type {
+	if content, ok := bytesAtOffset(word, full, numFields); ok {
+		if isPrintable(content) {
+			return StringType()
+		}
+		return BytesType()
+	}
+
+	switch {
+	case !isNonZero(word[:12]) && isNonZero(word[12:16]):
+		return AddressType()
+	case bytes.Count(word[:16], []byte{0xff}) == 16:
+		return IntType(256)
+	}
+	return UintType(256)
+}
+
+// bytesAtOffset reports whether word is a plausible offset into the tail
+// of full that points to a well formed bytes value, and returns its
+// content.
+func bytesAtOffset(word, full []byte, numFields int) ([]byte, bool) {
+	offset, err := DecodeUint64(word)
+	switch {
+	case err != nil:
+		return nil, false
+	case offset%32 != 0:
+		return nil, false
+	case offset < uint64(32*numFields) || offset > uint64(len(full)):
+		return nil, false
+	case uint64(len(full))-offset < 32:
+		return nil, false
+	}
+
+	content, err := decodeBytesAt(full[offset:], &DecodeOptions{})
+	if err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// isPrintable reports whether b is non-empty, valid utf-8 and free of
+// control characters other than whitespace.
+func isPrintable(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
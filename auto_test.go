@@ -0,0 +1,84 @@
+package abi_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestDecodeTupleAuto(t *testing.T) {
+	t.Run("offset near max uint64", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(math.MaxUint64 - 31)
+		// when
+		var types []abi.Type
+		var err error
+		require.NotPanics(t, func() { _, types, err = abi.DecodeTupleAuto(input, 1) })
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []abi.Type{abi.UintType(256)}, types)
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		addr := someAddress()
+		schema := []abi.Type{
+			abi.UintType(256),
+			abi.AddressType(),
+			abi.IntType(256),
+			abi.StringType(),
+			abi.BytesType(),
+		}
+		native := []any{
+			big.NewInt(42),
+			addr,
+			big.NewInt(-7),
+			"hello world",
+			[]byte{0xde, 0xad, 0x00, 0xbe, 0xef},
+		}
+		input, err := abi.Encode(schema, native)
+		require.NoError(t, err)
+
+		// when
+		values, types, err := abi.DecodeTupleAuto(input, len(schema))
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, schema, types)
+		assert.Equal(t, native, values)
+	})
+
+	t.Run("ambiguous slot falls back to uint256", func(t *testing.T) {
+		// given
+		// the value 64 looks like an offset, but there is no valid bytes
+		// value at that position
+		input := append(abi.EncodeUint64(64), abi.EncodeUint64(1)...)
+
+		// when
+		values, types, err := abi.DecodeTupleAuto(input, 2)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []abi.Type{abi.UintType(256), abi.UintType(256)}, types)
+		assert.Equal(t, []any{big.NewInt(64), big.NewInt(1)}, values)
+	})
+
+	t.Run("invalid field count", func(t *testing.T) {
+		// when
+		_, _, err := abi.DecodeTupleAuto(nZeros(32), 0)
+		// then
+		assert.ErrorContains(t, err, "invalid field count 0")
+	})
+
+	t.Run("not long enough", func(t *testing.T) {
+		// when
+		_, _, err := abi.DecodeTupleAuto(nZeros(32), 2)
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all fields")
+	})
+}
@@ -0,0 +1,92 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EncodeBool encodes a bool to 32-byte ABI format, that is, as 31 zero
+// bytes followed by 0x01 for true or 0x00 for false.  It is the inverse
+// operation of DecodeBool.
+func EncodeBool(v bool) []byte {
+	word := make([]byte, 32)
+	if v {
+		word[31] = 1
+	}
+	return word
+}
+
+// DecodeBool decodes ABI bytes back to a bool.  Only the canonical
+// encodings of 0 and 1 are accepted, any other value is an error rather
+// than being truncated.  It is the inverse operation of EncodeBool.
+func DecodeBool(v []byte) (bool, error) {
+	switch {
+	case len(v) == 0:
+		return false, ErrEmptyInput
+	case len(v) != 32:
+		return false, errors.New("bool encoding must contain 32 bytes")
+	}
+	return decodeBool(v)
+}
+
+// EncodeTupleFuncBool encodes a bool as the k-th element of a tuple.
+func EncodeTupleFuncBool(v bool) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data := EncodeBool(v)
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncBool decodes a bool as the k-th element of a tuple.
+func DecodeTupleFuncBool(v *bool) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := DecodeBool(cur[:32])
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		*v = vv
+		return nil
+	}
+}
+
+// EncodeSliceOfBool encodes a slice of bools to a bool[].  The elements
+// are static, so they are stored inline after the element count, without
+// offsets.  It is the inverse operation of DecodeSliceOfBool.
+func EncodeSliceOfBool(v []bool) []byte {
+	out := make([]byte, 0, 64+32*len(v))
+	out = append(out, precomputedSliceHeader...)
+	out = append(out, EncodeUint64(uint64(len(v)))...)
+	for i := range v {
+		out = append(out, EncodeBool(v[i])...)
+	}
+	return out
+}
+
+// DecodeSliceOfBool decodes a slice of bools from a bool[].  As for
+// DecodeBool, every element must be the canonical encoding of 0 or 1.  It
+// is the inverse operation of EncodeSliceOfBool.
+func DecodeSliceOfBool(abiEncoded []byte) ([]bool, error) {
+	switch {
+	case len(abiEncoded) == 0:
+		return nil, ErrEmptyInput
+	case len(abiEncoded) < 32:
+		return nil, errors.New("not long enough to have a head")
+	case !sliceEqual(abiEncoded[:32], precomputedSliceHeader):
+		return nil, errors.New("not a slice type")
+	}
+
+	words, err := splitSliceOfStatic(abiEncoded[32:], &DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]bool, len(words))
+	for i := range words {
+		results[i], err = decodeBool(words[i])
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,186 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeDecodeBool(t *testing.T) {
+	for _, tc := range []struct {
+		value   bool
+		encoded []byte
+	}{
+		{true, abi.EncodeUint64(1)},
+		{false, abi.EncodeUint64(0)},
+	} {
+		// when
+		encoded := abi.EncodeBool(tc.value)
+		got, err := abi.DecodeBool(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, tc.encoded, encoded)
+		assert.Equal(t, tc.value, got)
+	}
+}
+
+func TestDecodeBool(t *testing.T) {
+	t.Run("non-canonical value", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeBool(abi.EncodeUint64(2))
+		// then
+		assert.ErrorContains(t, err, "invalid bool value")
+	})
+
+	t.Run("high bytes set", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(1)
+		input[0] = 1
+		// when
+		_, err := abi.DecodeBool(input)
+		// then
+		assert.ErrorContains(t, err, "invalid bool value")
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeBool(nZeros(31))
+		// then
+		assert.ErrorContains(t, err, "bool encoding must contain 32 bytes")
+	})
+}
+
+func TestTupleEncoderDecoder_Bool(t *testing.T) {
+	// given
+	encoded, err := abi.NewTupleEncoder().Bool(true).Uint64(3).Bool(false).Encode()
+	require.NoError(t, err)
+
+	// when
+	var a, b bool
+	var u uint64
+	err = abi.NewTupleDecoder().Bool(&a).Uint64(&u).Bool(&b).Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	want, err := abi.Encode(
+		[]abi.Type{abi.BoolType(), abi.UintType(64), abi.BoolType()},
+		[]any{true, 3, false},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, encoded)
+	assert.True(t, a)
+	assert.Equal(t, uint64(3), u)
+	assert.False(t, b)
+}
+
+func TestTupleFuncBool(t *testing.T) {
+	t.Run("round trip next to a dynamic element", func(t *testing.T) {
+		// given
+		want, err := abi.Encode(
+			[]abi.Type{abi.UintType(64), abi.BoolType(), abi.BytesType(), abi.BoolType()},
+			[]any{7, true, []byte("hello"), false},
+		)
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			Uint64(7).
+			Bool(true).
+			Bytes([]byte("hello")).
+			Bool(false).
+			Encode()
+		require.NoError(t, err)
+
+		var u uint64
+		var a, b bool
+		var bs []byte
+		err = abi.NewTupleDecoder().Uint64(&u).Bool(&a).Bytes(&bs).Bool(&b).Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, uint64(7), u)
+		assert.True(t, a)
+		assert.Equal(t, []byte("hello"), bs)
+		assert.False(t, b)
+	})
+
+	t.Run("non-canonical word", func(t *testing.T) {
+		// given
+		input, err := abi.NewTupleEncoder().Uint64(7).Uint64(2).Encode()
+		require.NoError(t, err)
+		var u uint64
+		var a bool
+
+		// when
+		err = abi.NewTupleDecoder().Uint64(&u).Bool(&a).Decode(input)
+
+		// then
+		assert.ErrorContains(t, err, "invalid bool value")
+	})
+}
+
+func TestEncodeDecodeSliceOfBool(t *testing.T) {
+	for _, v := range [][]bool{{}, {true}, {true, false, false, true}} {
+		// given
+		elems := make([]any, len(v))
+		for i := range v {
+			elems[i] = v[i]
+		}
+		want, err := abi.EncodeValue(abi.SliceType(abi.BoolType()), elems)
+		require.NoError(t, err)
+
+		// when
+		encoded := abi.EncodeSliceOfBool(v)
+		got, err := abi.DecodeSliceOfBool(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, v, got)
+	}
+}
+
+func TestDecodeSliceOfBool(t *testing.T) {
+	flags := abi.EncodeSliceOfBool([]bool{true, false})
+
+	t.Run("non-canonical element", func(t *testing.T) {
+		// given
+		input := append([]byte{}, flags...)
+		input[len(input)-1] = 2
+		// when
+		_, err := abi.DecodeSliceOfBool(input)
+		// then
+		assert.ErrorContains(t, err, "decoding element 1, invalid bool value")
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		input := append([]byte{}, flags...)
+		input[31] = 0x40
+		// when
+		_, err := abi.DecodeSliceOfBool(input)
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("misaligned", func(t *testing.T) {
+		// given
+		input := append(append([]byte{}, flags...), 0)
+		// when
+		_, err := abi.DecodeSliceOfBool(input)
+		// then
+		assert.ErrorContains(t, err, "slice of 2 elements must contain 64 bytes")
+	})
+
+	t.Run("too short to have a head", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeSliceOfBool(nZeros(31))
+		// then
+		assert.ErrorContains(t, err, "not long enough to have a head")
+	})
+}
@@ -0,0 +1,396 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"unicode/utf8"
+)
+
+// DecodeOptions configures the limits applied while decoding.  Limits
+// apply uniformly at every level of nesting.  A limit that is zero is not
+// enforced, so the zero value decodes without limits.
+type DecodeOptions struct {
+	// MaxBytes is the maximum length of a single bytes or string value.
+	MaxBytes int
+	// MaxElements is the maximum number of elements of a single slice.
+	MaxElements int
+	// MaxDepth is the maximum nesting of slices, arrays and tuples.
+	MaxDepth int
+	// MaxTotalBytes is the maximum combined length of all bytes<N>, bytes
+	// and string values, across all fields and levels of nesting.  It
+	// bounds the output of payloads whose values are individually small.
+	MaxTotalBytes int
+	// AbsoluteOffsets interprets the offsets of nested values as relative
+	// to the start of the whole input, rather than to the start of the
+	// enclosing slice, array or tuple.  This is NOT ABI compliant, it is a
+	// compatibility shim for decoding the output of tools that encode
+	// nested offsets in that way.
+	AbsoluteOffsets bool
+	// DetectAbsoluteOffsets explains a failure to decode data with nested
+	// dynamic values by decoding it a second time with AbsoluteOffsets,
+	// and returns ErrAbsoluteOffsets if that succeeds.  It is meant for
+	// diagnosing inputs of unknown origin, as every failure pays for the
+	// second decode.
+	DetectAbsoluteOffsets bool
+
+	// root is the whole input, against which absolute offsets resolve.
+	root []byte
+	// totalBytes counts the bytes decoded so far against MaxTotalBytes.
+	totalBytes int
+	// collectOffsets records the position of each resolved offset in
+	// offsets, for CollectOffsets.
+	collectOffsets bool
+	offsets        []uint64
+}
+
+// ErrAbsoluteOffsets is returned by Decode with
+// DecodeOptions.DetectAbsoluteOffsets when data fails to decode, but would
+// decode if the offsets of nested values were relative to the start
+// of data.  Such data was produced by a tool that does not follow the ABI
+// specification, where offsets are relative to the start of the enclosing
+// tuple, slice or array.  It can be decoded by setting
+// DecodeOptions.AbsoluteOffsets.
+var ErrAbsoluteOffsets = errors.New(
+	"offsets are relative to the start of the input rather than the enclosing value, " +
+		"see DecodeOptions.AbsoluteOffsets",
+)
+
+// charge counts n decoded bytes against the MaxTotalBytes budget.
+func (o *DecodeOptions) charge(n int) error {
+	o.totalBytes += n
+	if o.MaxTotalBytes > 0 && o.totalBytes > o.MaxTotalBytes {
+		return errors.New("total decoded size exceeds limit")
+	}
+	return nil
+}
+
+// Decode decodes data as a tuple whose fields are described by schema.
+// The decoded values are returned in schema order as:
+//
+//   - *big.Int for uint<N> and int<N>
+//   - bool for bool
+//   - [20]byte for address
+//   - []byte for bytes<N> and bytes
+//   - string for string
+//   - []any for slices, arrays and tuples
+func Decode(data []byte, schema []Type, opts DecodeOptions) ([]any, error) {
+	return decode(data, schema, &opts)
+}
+
+func decode(data []byte, schema []Type, opts *DecodeOptions) ([]any, error) {
+	for i := range schema {
+		if err := schema[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid type for element %d: %w", i, err)
+		}
+	}
+
+	// the schema is the components of a tuple, checked as one so that
+	// together they fit in a head
+	if _, err := TupleType(schema...).checkedHeadSize(); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if len(data) == 0 && headSlots(schema) > 0 {
+		return nil, ErrEmptyInput
+	}
+
+	opts.root = data
+	values, err := decodeSequence(data, len(schema), func(i int) Type {
+		return schema[i]
+	}, opts, 0)
+	if err != nil && opts.DetectAbsoluteOffsets && !opts.AbsoluteOffsets &&
+		hasAbsoluteOffsets(data, schema, opts) {
+		return nil, fmt.Errorf("%w: %w", ErrAbsoluteOffsets, err)
+	}
+	return values, err
+}
+
+// hasAbsoluteOffsets reports whether data, which failed to decode, decodes
+// when the offsets of nested values are taken as relative to the start of
+// data, so that the failure can be explained.  The limits of opts apply.
+// Schemas without nested offsets decode the same either way, so they are
+// not decoded again.
+func hasAbsoluteOffsets(data []byte, schema []Type, opts *DecodeOptions) bool {
+	if !slices.ContainsFunc(schema, Type.hasNestedOffsets) {
+		return false
+	}
+
+	retry := DecodeOptions{
+		MaxBytes:        opts.MaxBytes,
+		MaxElements:     opts.MaxElements,
+		MaxDepth:        opts.MaxDepth,
+		MaxTotalBytes:   opts.MaxTotalBytes,
+		AbsoluteOffsets: true,
+		root:            data,
+	}
+	_, err := decodeSequence(data, len(schema), func(i int) Type {
+		return schema[i]
+	}, &retry, 0)
+	return err == nil
+}
+
+// DecodeValue decodes data as the encoding of a single value of type t,
+// that is, as a tuple with a single field.  See Decode for the go types
+// of the returned value.
+func DecodeValue(data []byte, t Type, opts DecodeOptions) (any, error) {
+	values, err := Decode(data, []Type{t}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// IsMinimumValid checks, without decoding anything, that data is long
+// enough to hold the head of a tuple whose fields are described by types
+// and that it is 32-byte aligned.  It is a cheap filter for inputs that
+// are obviously malformed, passing it does not mean that Decode succeeds.
+// Invalid types, including those whose head is too large, are rejected.
+func IsMinimumValid(data []byte, types []Type) error {
+	slots, err := HeadSlots(types)
+	if err != nil {
+		return fmt.Errorf("invalid types: %w", err)
+	}
+	switch {
+	case len(data) == 0 && slots > 0:
+		return ErrEmptyInput
+	case len(data)%32 != 0:
+		return fmt.Errorf("invalid length '%d' not 32-byte aligned", len(data))
+	case len(data) < 32*slots:
+		return errors.New("not long enough to support all elements")
+	}
+	return nil
+}
+
+// CollectOffsets decodes data as a tuple whose fields are described by
+// types, with the same validation as Decode, and returns every dynamic
+// offset that was resolved, including those of nested values, in the
+// order they were encountered.  Each offset is returned as the position
+// in data that it resolves to, so that overlapping or backward pointing
+// offsets can be checked for directly.
+func CollectOffsets(data []byte, types []Type) ([]uint64, error) {
+	opts := DecodeOptions{collectOffsets: true}
+	if _, err := decode(data, types, &opts); err != nil {
+		return nil, err
+	}
+
+	if opts.offsets == nil {
+		return []uint64{}, nil
+	}
+	return opts.offsets, nil
+}
+
+// decodeSequence decodes n values laid out as the fields of a tuple, where
+// typeAt gives the type of the i-th value.  Offsets of dynamic values are
+// relative to the start of data, unless opts.AbsoluteOffsets is set and
+// the values are nested, in which case they are relative to opts.root.
+func decodeSequence(
+	data []byte,
+	n int,
+	typeAt func(i int) Type,
+	opts *DecodeOptions,
+	depth int,
+) ([]any, error) {
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return nil, fmt.Errorf("nesting depth exceeds limit %d", opts.MaxDepth)
+	}
+
+	types := make([]Type, n)
+	for i := range n {
+		types[i] = typeAt(i)
+	}
+	if len(data) < 32*headSlots(types) {
+		return nil, errors.New("not long enough to support all elements")
+	}
+
+	base := data
+	if opts.AbsoluteOffsets && depth > 0 {
+		base = opts.root
+	}
+
+	values := make([]any, n)
+	pos := 0
+	for i, t := range types {
+		size := t.headSize()
+
+		region := data[pos : pos+size]
+		if t.IsDynamic() {
+			offset, err := DecodeUint64(region)
+			switch {
+			case err != nil:
+				return nil, fmt.Errorf("decoding offset of element %d: %w", i, err)
+			case offset > uint64(len(base)):
+				return nil, fmt.Errorf("offset of element %d out of bounds", i)
+			}
+			region = base[offset:]
+
+			if opts.collectOffsets {
+				// every region is a subslice of root that shares its end,
+				// so the difference in capacity is the start of base
+				start := cap(opts.root) - cap(base)
+				opts.offsets = append(opts.offsets, uint64(start)+offset)
+			}
+		}
+
+		v, err := decodeType(region, t, opts, depth)
+		if err != nil {
+			return nil, &DecodeError{ElementIndex: i, Offset: uint64(pos), Cause: err}
+		}
+		values[i] = v
+		pos += size
+	}
+
+	return values, nil
+}
+
+// decodeType decodes a value of type t stored at the start of data.  Data
+// may extend past the end of the value.
+func decodeType(data []byte, t Type, opts *DecodeOptions, depth int) (any, error) {
+	var word []byte
+	switch t.Kind {
+	case ArrayKind, TupleKind:
+	default:
+		if len(data) < 32 {
+			return nil, errors.New("not long enough to hold a word")
+		}
+		word = data[:32:32]
+	}
+
+	switch t.Kind {
+	case UintKind:
+		return decodeUint(word, t.Size)
+	case IntKind:
+		return decodeInt(word, t.Size)
+	case BoolKind:
+		return decodeBool(word)
+	case AddressKind:
+		return DecodeAddress(word)
+	case FixedBytesKind:
+		if err := opts.charge(t.Size); err != nil {
+			return nil, err
+		}
+		return decodeFixedBytes(word, t.Size)
+	case BytesKind:
+		return decodeBytesAt(data, opts)
+	case StringKind:
+		b, err := decodeBytesAt(data, opts)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(b) {
+			return nil, errors.New("string is not valid utf-8")
+		}
+		return string(b), nil
+	case SliceKind:
+		count, err := DecodeUint64(word)
+		if err != nil {
+			return nil, fmt.Errorf("decoding element count, %w", err)
+		}
+
+		elems := data[32:]
+		switch {
+		case opts.MaxElements > 0 && count > uint64(opts.MaxElements):
+			format := "element count %d exceeds limit %d"
+			return nil, fmt.Errorf(format, count, opts.MaxElements)
+		case count > uint64(len(elems)/t.Elem.headSize()):
+			return nil, fmt.Errorf("tail too short for %d elements", count)
+		}
+
+		return decodeSequence(elems, int(count), func(int) Type {
+			return *t.Elem
+		}, opts, depth+1)
+	case ArrayKind:
+		// the length is checked before decodeSequence collects the types
+		// of the elements, which a short input must not pay for
+		if len(data)/t.Size < t.Elem.headSize() {
+			return nil, fmt.Errorf("not long enough to hold %d elements", t.Size)
+		}
+		return decodeSequence(data, t.Size, func(int) Type {
+			return *t.Elem
+		}, opts, depth+1)
+	case TupleKind:
+		return decodeSequence(data, len(t.Components), func(i int) Type {
+			return t.Components[i]
+		}, opts, depth+1)
+	}
+	return nil, fmt.Errorf("unknown type kind %d", t.Kind)
+}
+
+// wordToBigInt interprets word as an unsigned big-endian integer.  Zero is
+// returned in the same form as big.NewInt(0), so that decoded values are
+// deeply equal to values built with the math/big constructors.
+func wordToBigInt(word []byte) *big.Int {
+	if !isNonZero(word) {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(word)
+}
+
+func decodeUint(word []byte, bits int) (*big.Int, error) {
+	v := wordToBigInt(word)
+	if v.BitLen() > bits {
+		return nil, fmt.Errorf("value out of range for uint%d", bits)
+	}
+	return v, nil
+}
+
+func decodeInt(word []byte, bits int) (*big.Int, error) {
+	// values are stored in two's complement, so a set top bit means that
+	// the value is negative and we need to subtract 2^256.
+	v := wordToBigInt(word)
+	if word[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	// a value fits in int<bits> when its magnitude, or for negative values
+	// its magnitude minus one, fits in bits-1 bits.
+	m := v
+	if v.Sign() < 0 {
+		m = new(big.Int).Not(v)
+	}
+	if m.BitLen() > bits-1 {
+		return nil, fmt.Errorf("value out of range for int%d", bits)
+	}
+	return v, nil
+}
+
+func decodeBool(word []byte) (bool, error) {
+	if isNonZero(word[:31]) || word[31] > 1 {
+		return false, errors.New("invalid bool value")
+	}
+	return word[31] == 1, nil
+}
+
+func decodeFixedBytes(word []byte, n int) ([]byte, error) {
+	data, padding := word[:n], word[n:]
+	if err := checkPadding(padding); err != nil {
+		return nil, err
+	}
+
+	dst := make([]byte, n)
+	copy(dst, data)
+	return dst, nil
+}
+
+// decodeBytesAt decodes a bytes value stored at the start of data.  Data
+// may extend past the end of the value.
+func decodeBytesAt(data []byte, opts *DecodeOptions) ([]byte, error) {
+	byteCount, err := DecodeUint64(data[:32])
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding length: %w", err)
+	case byteCount > uint64(len(data)-32):
+		return nil, fmt.Errorf("length in head is out of range")
+	}
+
+	alignedLen, err := nextMultipleOf32(int(byteCount))
+	switch {
+	case err != nil:
+		return nil, err
+	case alignedLen > len(data)-32:
+		return nil, fmt.Errorf("end is out of bounds")
+	}
+	end := 32 + alignedLen
+
+	return decodeBytes(data[:end], opts)
+}
@@ -0,0 +1,631 @@
+package abi_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func bigInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big int " + s)
+	}
+	return v
+}
+
+// mixedTypes is encoded by go-ethereum and covers every kind of type.
+var mixedTypes = struct {
+	schema  []abi.Type
+	native  []any
+	encoded []byte
+}{
+	schema: []abi.Type{
+		abi.UintType(256),
+		abi.IntType(8),
+		abi.BoolType(),
+		abi.AddressType(),
+		abi.FixedBytesType(4),
+		abi.BytesType(),
+		abi.StringType(),
+		abi.SliceType(abi.UintType(64)),
+		abi.ArrayType(abi.TupleType(abi.UintType(8), abi.BytesType()), 2),
+		abi.IntType(256),
+		abi.ArrayType(abi.UintType(16), 2),
+	},
+	native: []any{
+		bigInt("123456789012345678901234567890"),
+		big.NewInt(-5),
+		true,
+		[20]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		[]byte{0xde, 0xad, 0xbe, 0xef},
+		[]byte("hello"),
+		"wörld",
+		[]any{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		[]any{
+			[]any{big.NewInt(7), []byte{1, 2}},
+			[]any{big.NewInt(8), nZeros(33)},
+		},
+		big.NewInt(-1000),
+		[]any{big.NewInt(9), big.NewInt(10)},
+	},
+	encoded: hexDecode("" +
+		"00000000000000000000000000000000000000018ee90ff6c373e0ee4e3f0ad2" +
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffb" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000102030405060708090a0b0c0d0e0f1011121314" +
+		"deadbeef00000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000180" +
+		"00000000000000000000000000000000000000000000000000000000000001c0" +
+		"0000000000000000000000000000000000000000000000000000000000000200" +
+		"0000000000000000000000000000000000000000000000000000000000000280" +
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc18" +
+		"0000000000000000000000000000000000000000000000000000000000000009" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"68656c6c6f000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000006" +
+		"77c3b6726c640000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000c0" +
+		"0000000000000000000000000000000000000000000000000000000000000007" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0102000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000008" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000021" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	),
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.Decode(mixedTypes.encoded, mixedTypes.schema, abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, mixedTypes.native, got)
+	})
+
+	t.Run("slice of bytes", func(t *testing.T) {
+		// given
+		want := [][]byte{[]byte("first"), []byte("second")}
+		input, err := abi.EncodeSliceOfBytes(want)
+		require.NoError(t, err)
+		schema := []abi.Type{abi.SliceType(abi.BytesType())}
+		// when
+		got, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []any{[]any{want[0], want[1]}}, got)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		// given
+		schema := []abi.Type{abi.UintType(7)}
+		// when
+		_, err := abi.Decode(nZeros(32), schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "invalid integer size 7")
+	})
+
+	for _, tc := range []struct {
+		name string
+		typ  abi.Type
+		want string
+	}{
+		{
+			name: "array head wraps around",
+			typ:  abi.ArrayType(abi.UintType(256), 1<<59),
+			want: "array of 576460752303423488 elements too large",
+		},
+		{
+			name: "array head past the limit",
+			typ:  abi.ArrayType(abi.UintType(256), 1<<58),
+			want: "array of 288230376151711744 elements too large",
+		},
+		{
+			name: "dynamic array with too many offsets",
+			typ:  abi.ArrayType(abi.BytesType(), 1<<58),
+			want: "array of 288230376151711744 elements too large",
+		},
+		{
+			name: "nested arrays",
+			typ:  abi.ArrayType(abi.ArrayType(abi.UintType(256), 1<<30), 1<<30),
+			want: "array of 1073741824 elements too large",
+		},
+		{
+			name: "tuple of large arrays",
+			typ: abi.TupleType(
+				abi.ArrayType(abi.UintType(256), 1<<52),
+				abi.ArrayType(abi.UintType(256), 1<<52),
+			),
+			want: "tuple too large",
+		},
+		{
+			name: "missing element type",
+			typ:  abi.Type{Kind: abi.ArrayKind, Size: 2},
+			want: "missing element type",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			var err error
+			require.NotPanics(t, func() {
+				_, err = abi.Decode(nZeros(64), []abi.Type{tc.typ}, abi.DecodeOptions{})
+			})
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+
+	t.Run("large array longer than the input", func(t *testing.T) {
+		// given
+		schema := []abi.Type{abi.ArrayType(abi.BytesType(), 1<<40)}
+		input := abi.EncodeUint64(32)
+		input = append(input, nZeros(32)...)
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "not long enough to hold 1099511627776 elements")
+	})
+
+	t.Run("too short for schema", func(t *testing.T) {
+		// given
+		schema := []abi.Type{abi.UintType(256), abi.UintType(256)}
+		// when
+		_, err := abi.Decode(nZeros(32), schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all elements")
+	})
+
+	t.Run("offset out of bounds", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(64)
+		schema := []abi.Type{abi.BytesType()}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "offset of element 0 out of bounds")
+	})
+
+	t.Run("dynamic tuple at end of data", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(32)
+		schema := []abi.Type{abi.TupleType(abi.BytesType())}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all elements")
+	})
+
+	t.Run("value out of range for uint8", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(256)
+		schema := []abi.Type{abi.UintType(8)}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "value out of range for uint8")
+	})
+
+	t.Run("value out of range for int8", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(128)
+		schema := []abi.Type{abi.IntType(8)}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "value out of range for int8")
+	})
+
+	t.Run("invalid bool", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(2)
+		schema := []abi.Type{abi.BoolType()}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "invalid bool value")
+	})
+
+	t.Run("fixed bytes with bad padding", func(t *testing.T) {
+		// given
+		input := bytes.Repeat([]byte{1}, 32)
+		schema := []abi.Type{abi.FixedBytesType(4)}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+
+	t.Run("string is not utf-8", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeTuple(abi.EncodeTupleFuncBytes([]byte{0xff}))
+		require.NoError(t, err)
+		schema := []abi.Type{abi.StringType()}
+		// when
+		_, err = abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "not valid utf-8")
+	})
+
+	t.Run("slice count out of range", func(t *testing.T) {
+		// given
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(2)...)
+		input = append(input, nZeros(32)...)
+		schema := []abi.Type{abi.SliceType(abi.UintType(256))}
+		// when
+		_, err := abi.Decode(input, schema, abi.DecodeOptions{})
+		// then
+		assert.ErrorContains(t, err, "tail too short for 2 elements")
+	})
+}
+
+func TestDecode_Options(t *testing.T) {
+	t.Run("max bytes", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeTuple(abi.EncodeTupleFuncBytes([]byte("hello")))
+		require.NoError(t, err)
+		schema := []abi.Type{abi.BytesType()}
+		opts := abi.DecodeOptions{MaxBytes: 4}
+		// when
+		_, err = abi.Decode(input, schema, opts)
+		// then
+		assert.ErrorContains(t, err, "length 5 exceeds limit 4")
+	})
+
+	t.Run("max bytes applies to nested values", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeSliceOfBytes([][]byte{[]byte("ok"), []byte("hello")})
+		require.NoError(t, err)
+		schema := []abi.Type{abi.SliceType(abi.BytesType())}
+		opts := abi.DecodeOptions{MaxBytes: 4}
+		// when
+		_, err = abi.Decode(input, schema, opts)
+		// then
+		assert.ErrorContains(t, err, "length 5 exceeds limit 4")
+	})
+
+	t.Run("max elements", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeSliceOfBytes([][]byte{{1}, {2}, {3}})
+		require.NoError(t, err)
+		schema := []abi.Type{abi.SliceType(abi.BytesType())}
+		opts := abi.DecodeOptions{MaxElements: 2}
+		// when
+		_, err = abi.Decode(input, schema, opts)
+		// then
+		assert.ErrorContains(t, err, "element count 3 exceeds limit 2")
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		// given
+		// uint256[][] holding a single empty slice
+		input := abi.SliceHeader()
+		input = append(input, abi.EncodeUint64(1)...)
+		input = append(input, abi.EncodeUint64(32)...)
+		input = append(input, abi.EncodeUint64(0)...)
+		schema := []abi.Type{abi.SliceType(abi.SliceType(abi.UintType(256)))}
+		// when
+		_, errAtLimit := abi.Decode(input, schema, abi.DecodeOptions{MaxDepth: 2})
+		_, errOverLimit := abi.Decode(input, schema, abi.DecodeOptions{MaxDepth: 1})
+		// then
+		assert.NoError(t, errAtLimit)
+		assert.ErrorContains(t, errOverLimit, "nesting depth exceeds limit 1")
+	})
+
+	t.Run("max total bytes", func(t *testing.T) {
+		// given
+		// a slice of slices of bytes where every value is below MaxBytes,
+		// but together they hold 5*4 bytes
+		value := []any{
+			[]any{[]byte("aaaa"), []byte("bbbb")},
+			[]any{[]byte("cccc"), []byte("dddd"), []byte("eeee")},
+		}
+		schema := []abi.Type{abi.SliceType(abi.SliceType(abi.BytesType()))}
+		input, err := abi.Encode(schema, []any{value})
+		require.NoError(t, err)
+		// when
+		_, errAtLimit := abi.Decode(input, schema, abi.DecodeOptions{MaxBytes: 4, MaxTotalBytes: 20})
+		_, errOverLimit := abi.Decode(input, schema, abi.DecodeOptions{MaxBytes: 4, MaxTotalBytes: 19})
+		// then
+		assert.NoError(t, errAtLimit)
+		assert.ErrorContains(t, errOverLimit, "total decoded size exceeds limit")
+	})
+}
+
+func TestDecodeValue(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := [][]byte{[]byte("some-bytes")}
+		input, err := abi.EncodeSliceOfBytes(want)
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeValue(input, abi.SliceType(abi.BytesType()), abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []any{want[0]}, got)
+	})
+}
+
+func TestDecode_NestedDynamicTuple(t *testing.T) {
+	t.Run("offsets are relative to the nested tuple", func(t *testing.T) {
+		// given
+		schema := []abi.Type{
+			abi.UintType(256),
+			abi.TupleType(abi.UintType(256), abi.BytesType()),
+			abi.BytesType(),
+		}
+		want := []any{
+			big.NewInt(7),
+			[]any{big.NewInt(8), []byte("inner")},
+			[]byte("outer"),
+		}
+		// when
+		got, err := abi.Decode(nestedDynamicTuple, schema, abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+// orders is encoded by go-ethereum as a ((bytes32[],uint256),address)[],
+// a slice of orders holding a nested struct with a dynamic array.
+var orders = struct {
+	typ     abi.Type
+	native  any
+	encoded []byte
+}{
+	typ: abi.MustParseType("((bytes32[],uint256),address)[]"),
+	native: []any{
+		[]any{
+			[]any{
+				[]any{bytes.Repeat([]byte{0x11}, 32)},
+				big.NewInt(1000),
+			},
+			someAddress(),
+		},
+		[]any{
+			[]any{
+				[]any{
+					bytes.Repeat([]byte{0x22}, 32),
+					bytes.Repeat([]byte{0x33}, 32),
+					bytes.Repeat([]byte{0x44}, 32),
+				},
+				big.NewInt(2000),
+			},
+			[20]byte{
+				0xa0, 0xa1, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7, 0xa8, 0xa9,
+				0xaa, 0xab, 0xac, 0xad, 0xae, 0xaf, 0xb0, 0xb1, 0xb2, 0xb3,
+			},
+		},
+	},
+	encoded: hexDecode("" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000100" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000102030405060708090a0b0c0d0e0f1011121314" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000003e8" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"1111111111111111111111111111111111111111111111111111111111111111" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"000000000000000000000000a0a1a2a3a4a5a6a7a8a9aaabacadaeafb0b1b2b3" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000007d0" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"2222222222222222222222222222222222222222222222222222222222222222" +
+		"3333333333333333333333333333333333333333333333333333333333333333" +
+		"4444444444444444444444444444444444444444444444444444444444444444",
+	),
+}
+
+func TestDecode_SliceOfNestedTuples(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeValue(orders.encoded, orders.typ, abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, orders.native, got)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeValue(orders.typ, orders.native)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, orders.encoded, got)
+	})
+}
+
+func TestDecode_AbsoluteOffsets(t *testing.T) {
+	// given
+	// nestedDynamicTuple as encoded by a tool that makes the offset of the
+	// inner bytes relative to the start of the input (0x60 + 0x40) rather
+	// than to the start of the inner tuple
+	buggy := append([]byte{}, nestedDynamicTuple...)
+	buggy[5*32-1] = 0xa0
+
+	schema := []abi.Type{
+		abi.UintType(256),
+		abi.TupleType(abi.UintType(256), abi.BytesType()),
+		abi.BytesType(),
+	}
+	want := []any{
+		big.NewInt(7),
+		[]any{big.NewInt(8), []byte("inner")},
+		[]byte("outer"),
+	}
+
+	t.Run("absolute offsets", func(t *testing.T) {
+		// when
+		got, err := abi.Decode(buggy, schema, abi.DecodeOptions{AbsoluteOffsets: true})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("relative offsets reject the payload", func(t *testing.T) {
+		// when
+		_, err := abi.Decode(buggy, schema, abi.DecodeOptions{})
+		// then
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, abi.ErrAbsoluteOffsets)
+	})
+
+	t.Run("detection explains the rejection", func(t *testing.T) {
+		// when
+		_, err := abi.Decode(buggy, schema, abi.DecodeOptions{DetectAbsoluteOffsets: true})
+		// then
+		assert.ErrorIs(t, err, abi.ErrAbsoluteOffsets)
+		assert.ErrorContains(t, err, "see DecodeOptions.AbsoluteOffsets")
+	})
+
+	t.Run("other failures are not blamed on the offsets", func(t *testing.T) {
+		// given
+		// the standard payload with its inner bytes padding corrupted
+		corrupt := append([]byte{}, nestedDynamicTuple...)
+		corrupt[7*32-1] = 0x01
+		// when
+		_, err := abi.Decode(corrupt, schema, abi.DecodeOptions{DetectAbsoluteOffsets: true})
+		// then
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, abi.ErrAbsoluteOffsets)
+	})
+
+	t.Run("relative offsets decode standard payloads", func(t *testing.T) {
+		// when
+		got, err := abi.Decode(nestedDynamicTuple, schema, abi.DecodeOptions{})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("absolute offsets reject standard payloads", func(t *testing.T) {
+		// when
+		_, err := abi.Decode(nestedDynamicTuple, schema, abi.DecodeOptions{AbsoluteOffsets: true})
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestIsMinimumValid(t *testing.T) {
+	types := []abi.Type{
+		abi.UintType(256),
+		abi.BytesType(),
+		abi.ArrayType(abi.AddressType(), 2),
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		types   []abi.Type
+		wantErr string
+	}{
+		{"exact head", make([]byte, 4*32), types, ""},
+		{"longer than head", make([]byte, 6*32), types, ""},
+		{"empty schema", nil, nil, ""},
+		{"too short", make([]byte, 3*32), types, "not long enough to support all elements"},
+		{"misaligned", make([]byte, 4*32+1), types, "invalid length '129' not 32-byte aligned"},
+		{"empty", nil, types, abi.ErrEmptyInput.Error()},
+		{
+			"head too large",
+			make([]byte, 2*32),
+			[]abi.Type{abi.ArrayType(abi.UintType(256), 1<<59)},
+			"invalid types: invalid type for element 0: " +
+				"array of 576460752303423488 elements too large",
+		},
+		{
+			"missing element type",
+			make([]byte, 2*32),
+			[]abi.Type{{Kind: abi.ArrayKind, Size: 2}},
+			"invalid types: invalid type for element 0: missing element type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			err := abi.IsMinimumValid(tt.data, tt.types)
+
+			// then
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCollectOffsets(t *testing.T) {
+	t.Run("two dynamic fields", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.BytesType(), abi.UintType(256), abi.StringType()}
+		data, err := abi.Encode(types, []any{[]byte("abc"), big.NewInt(7), "hello"})
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.CollectOffsets(data, types)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{3 * 32, 5 * 32}, got)
+	})
+
+	t.Run("nested offsets", func(t *testing.T) {
+		// given
+		// the slice starts at 32 and its elements, whose offsets are
+		// relative to the word after the count, at 128 and 192
+		types := []abi.Type{abi.SliceType(abi.BytesType())}
+		data, err := abi.Encode(types, []any{[]any{[]byte("a"), []byte("b")}})
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.CollectOffsets(data, types)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{32, 128, 192}, got)
+	})
+
+	t.Run("static fields", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.BoolType()}
+		data := abi.EncodeUint64(1)
+
+		// when
+		got, err := abi.CollectOffsets(data, types)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.BytesType()}
+		data := abi.EncodeUint64(64)
+
+		// when
+		_, err := abi.CollectOffsets(data, types)
+
+		// then
+		assert.ErrorContains(t, err, "offset of element 0 out of bounds")
+	})
+}
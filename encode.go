@@ -0,0 +1,201 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Encode encodes values as a tuple whose fields are described by schema.
+// It is the inverse operation of Decode and accepts values of the go types
+// returned by Decode.  For convenience, integer values may also be given
+// as any of the go integer types.
+func Encode(schema []Type, values []any) ([]byte, error) {
+	if len(schema) != len(values) {
+		format := "schema has %d elements but got %d values"
+		return nil, fmt.Errorf(format, len(schema), len(values))
+	}
+
+	results := make([]EncoderResult, len(schema))
+	for i := range schema {
+		if err := schema[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid type for element %d: %w", i, err)
+		}
+
+		res, err := encodeType(schema[i], values[i])
+		if err != nil {
+			return nil, fmt.Errorf("encoding element %d: %w", i, err)
+		}
+		results[i] = res
+	}
+
+	return assembleTuple(results)
+}
+
+// EncodeValue encodes v as a single value of type t, that is, as a tuple
+// with a single field.  It is the inverse operation of DecodeValue.
+func EncodeValue(t Type, v any) ([]byte, error) {
+	return Encode([]Type{t}, []any{v})
+}
+
+// encodeType encodes a value of type t into an EncoderResult that can be
+// laid out as an element of a tuple.
+func encodeType(t Type, v any) (EncoderResult, error) {
+	switch t.Kind {
+	case UintKind:
+		word, err := encodeUint(v, t.Size)
+		return EncoderResult{indirect: false, data: word}, err
+	case IntKind:
+		word, err := encodeInt(v, t.Size)
+		return EncoderResult{indirect: false, data: word}, err
+	case BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return EncoderResult{}, typeMismatch(t, v)
+		}
+		return EncoderResult{indirect: false, data: EncodeBool(b)}, nil
+	case AddressKind:
+		addr, ok := v.([20]byte)
+		if !ok {
+			return EncoderResult{}, typeMismatch(t, v)
+		}
+		return EncoderResult{indirect: false, data: EncodeAddress(addr)}, nil
+	case FixedBytesKind:
+		b, ok := v.([]byte)
+		switch {
+		case !ok:
+			return EncoderResult{}, typeMismatch(t, v)
+		case len(b) != t.Size:
+			return EncoderResult{}, fmt.Errorf("%s value must contain %d bytes", t, t.Size)
+		}
+		word, err := padRight(b, 32)
+		return EncoderResult{indirect: false, data: word}, err
+	case BytesKind:
+		b, ok := v.([]byte)
+		if !ok {
+			return EncoderResult{}, typeMismatch(t, v)
+		}
+		data, err := EncodeBytes(b)
+		return EncoderResult{indirect: true, data: data}, err
+	case StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return EncoderResult{}, typeMismatch(t, v)
+		}
+		data, err := EncodeBytes([]byte(s))
+		return EncoderResult{indirect: true, data: data}, err
+	case SliceKind:
+		elems, ok := v.([]any)
+		if !ok {
+			return EncoderResult{}, typeMismatch(t, v)
+		}
+		data, err := encodeSequence(elems, func(int) Type { return *t.Elem })
+		if err != nil {
+			return EncoderResult{}, err
+		}
+		data = append(EncodeUint64(uint64(len(elems))), data...)
+		return EncoderResult{indirect: true, data: data}, nil
+	case ArrayKind:
+		elems, ok := v.([]any)
+		switch {
+		case !ok:
+			return EncoderResult{}, typeMismatch(t, v)
+		case len(elems) != t.Size:
+			return EncoderResult{}, fmt.Errorf("%s value must contain %d elements", t, t.Size)
+		}
+		data, err := encodeSequence(elems, func(int) Type { return *t.Elem })
+		return EncoderResult{indirect: t.IsDynamic(), data: data}, err
+	case TupleKind:
+		fields, ok := v.([]any)
+		switch {
+		case !ok:
+			return EncoderResult{}, typeMismatch(t, v)
+		case len(fields) != len(t.Components):
+			format := "%s value must contain %d fields"
+			return EncoderResult{}, fmt.Errorf(format, t, len(t.Components))
+		}
+		data, err := encodeSequence(fields, func(i int) Type { return t.Components[i] })
+		return EncoderResult{indirect: t.IsDynamic(), data: data}, err
+	}
+	return EncoderResult{}, fmt.Errorf("unknown type kind %d", t.Kind)
+}
+
+// encodeSequence lays out values as the fields of a tuple, where typeAt
+// gives the type of the i-th value.
+func encodeSequence(values []any, typeAt func(i int) Type) ([]byte, error) {
+	results := make([]EncoderResult, len(values))
+	for i := range values {
+		res, err := encodeType(typeAt(i), values[i])
+		if err != nil {
+			return nil, fmt.Errorf("encoding element %d: %w", i, err)
+		}
+		results[i] = res
+	}
+	return assembleTuple(results)
+}
+
+func typeMismatch(t Type, v any) error {
+	return fmt.Errorf("cannot encode %T as %s", v, t)
+}
+
+// toBigInt converts the go integer types accepted by Encode to a big.Int.
+func toBigInt(v any) (*big.Int, bool) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, v != nil
+	case int:
+		return big.NewInt(int64(v)), true
+	case int8:
+		return big.NewInt(int64(v)), true
+	case int16:
+		return big.NewInt(int64(v)), true
+	case int32:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	}
+	return nil, false
+}
+
+func encodeUint(v any, bits int) ([]byte, error) {
+	n, ok := toBigInt(v)
+	switch {
+	case !ok:
+		return nil, typeMismatch(UintType(bits), v)
+	case n.Sign() < 0 || n.BitLen() > bits:
+		return nil, fmt.Errorf("value out of range for uint%d", bits)
+	}
+
+	return padLeft(n.Bytes(), 32)
+}
+
+func encodeInt(v any, bits int) ([]byte, error) {
+	n, ok := toBigInt(v)
+	if !ok {
+		return nil, typeMismatch(IntType(bits), v)
+	}
+
+	// see decodeInt for the range check
+	m := n
+	if n.Sign() < 0 {
+		m = new(big.Int).Not(n)
+	}
+	if m.BitLen() > bits-1 {
+		return nil, fmt.Errorf("value out of range for int%d", bits)
+	}
+
+	// negative values are stored in two's complement, that is, as 2^256+n
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return padLeft(n.Bytes(), 32)
+}
@@ -0,0 +1,109 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.Encode(mixedTypes.schema, mixedTypes.native)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, mixedTypes.encoded, got)
+	})
+
+	t.Run("go integer types", func(t *testing.T) {
+		// given
+		schema := []abi.Type{abi.UintType(64), abi.IntType(8)}
+		want, err := abi.Encode(schema, []any{big.NewInt(7), big.NewInt(-7)})
+		require.NoError(t, err)
+		// when
+		got, err := abi.Encode(schema, []any{uint64(7), int8(-7)})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	for _, tc := range []struct {
+		name  string
+		typ   string
+		value any
+		want  string
+	}{
+		{name: "wrong go type", typ: "bool", value: 1, want: "cannot encode int as bool"},
+		{name: "negative uint", typ: "uint256", value: -1, want: "value out of range for uint256"},
+		{name: "uint too large", typ: "uint8", value: 256, want: "value out of range for uint8"},
+		{name: "int too large", typ: "int8", value: 128, want: "value out of range for int8"},
+		{name: "int too small", typ: "int8", value: -129, want: "value out of range for int8"},
+		{name: "fixed bytes length", typ: "bytes4", value: []byte{1}, want: "must contain 4 bytes"},
+		{name: "array length", typ: "bool[2]", value: []any{true}, want: "must contain 2 elements"},
+		{name: "tuple fields", typ: "(bool,bool)", value: []any{true}, want: "must contain 2 fields"},
+		{name: "slice element", typ: "bool[]", value: []any{1}, want: "encoding element 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := abi.EncodeValue(abi.MustParseType(tc.typ), tc.value)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+
+	t.Run("schema and values differ in length", func(t *testing.T) {
+		// when
+		_, err := abi.Encode([]abi.Type{abi.BoolType()}, []any{})
+		// then
+		assert.ErrorContains(t, err, "schema has 1 elements but got 0 values")
+	})
+}
+
+func hexAddress(s string) [20]byte {
+	var addr [20]byte
+	copy(addr[:], hexDecode(s))
+	return addr
+}
+
+func TestEncode_SwapCalldata(t *testing.T) {
+	// given
+	// calldata for a Uniswap V2 router swap of 1 WETH for at least 2500 USDC,
+	// as produced by go-ethereum
+	const sig = "swapExactTokensForTokens(uint256,uint256,address[],address,uint256)"
+	want := hexDecode("" +
+		"38ed1739" +
+		"0000000000000000000000000000000000000000000000000de0b6b3a7640000" +
+		"000000000000000000000000000000000000000000000000000000009502f900" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"000000000000000000000000742d35cc6634c0532925a3b844bc454e4438f44e" +
+		"000000000000000000000000000000000000000000000000000000006553f100" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" +
+		"000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+	)
+	weth := hexAddress("c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	usdc := hexAddress("a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	recipient := hexAddress("742d35cc6634c0532925a3b844bc454e4438f44e")
+
+	_, types, err := abi.ParseSignature(sig)
+	require.NoError(t, err)
+	hash := abi.Keccak256([]byte(sig))
+
+	// when
+	args, err := abi.Encode(types, []any{
+		bigInt("1000000000000000000"),
+		uint64(2_500_000_000),
+		[]any{weth, usdc},
+		recipient,
+		uint64(1_700_000_000),
+	})
+	require.NoError(t, err)
+	got := append(hash[:4:4], args...)
+
+	// then
+	assert.Equal(t, want, got)
+}
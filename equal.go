@@ -0,0 +1,58 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TupleEqual reports whether a and b encode the same tuple of values
+// whose fields are described by types.  Both inputs are decoded and the
+// decoded values are compared, so encodings that differ only in
+// non-canonical padding, such as extra trailing words or unused space
+// between the head and the tail, compare equal.
+func TupleEqual(a, b []byte, types []Type) (bool, error) {
+	valuesA, err := Decode(a, types, DecodeOptions{})
+	if err != nil {
+		return false, fmt.Errorf("decoding a: %w", err)
+	}
+
+	valuesB, err := Decode(b, types, DecodeOptions{})
+	if err != nil {
+		return false, fmt.Errorf("decoding b: %w", err)
+	}
+
+	return valueEqual(valuesA, valuesB), nil
+}
+
+// valueEqual compares two values of the go types returned by Decode.
+func valueEqual(a, b any) bool {
+	switch a := a.(type) {
+	case *big.Int:
+		b, ok := b.(*big.Int)
+		return ok && a.Cmp(b) == 0
+	case bool:
+		b, ok := b.(bool)
+		return ok && a == b
+	case [20]byte:
+		b, ok := b.([20]byte)
+		return ok && AddressEqual(a, b)
+	case []byte:
+		b, ok := b.([]byte)
+		return ok && sliceEqual(a, b)
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !valueEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestTupleEqual(t *testing.T) {
+	t.Run("identical encodings", func(t *testing.T) {
+		// when
+		got, err := abi.TupleEqual(mixedTypes.encoded, mixedTypes.encoded, mixedTypes.schema)
+		require.NoError(t, err)
+		// then
+		assert.True(t, got)
+	})
+
+	t.Run("extra trailing padding", func(t *testing.T) {
+		// given
+		padded := append(append([]byte{}, mixedTypes.encoded...), nZeros(32)...)
+		// when
+		got, err := abi.TupleEqual(mixedTypes.encoded, padded, mixedTypes.schema)
+		require.NoError(t, err)
+		// then
+		assert.True(t, got)
+	})
+
+	t.Run("gap between head and tail", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.UintType(64), abi.BytesType()}
+		canonical, err := abi.Encode(types, []any{uint64(1), []byte("abc")})
+		require.NoError(t, err)
+
+		gapped := append([]byte{}, canonical[:64]...)
+		gapped = append(gapped, nZeros(32)...)
+		gapped = append(gapped, canonical[64:]...)
+		gapped[63] = 0x60
+
+		// when
+		got, err := abi.TupleEqual(canonical, gapped, types)
+		require.NoError(t, err)
+		// then
+		assert.True(t, got)
+	})
+
+	t.Run("different values", func(t *testing.T) {
+		// given
+		types := []abi.Type{abi.IntType(256), abi.StringType()}
+		a, err := abi.Encode(types, []any{-1, "abc"})
+		require.NoError(t, err)
+		b, err := abi.Encode(types, []any{-1, "abd"})
+		require.NoError(t, err)
+		// when
+		got, err := abi.TupleEqual(a, b, types)
+		require.NoError(t, err)
+		// then
+		assert.False(t, got)
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		// when
+		_, err := abi.TupleEqual(mixedTypes.encoded, []byte{1}, mixedTypes.schema)
+		// then
+		assert.ErrorContains(t, err, "decoding b")
+	})
+}
@@ -0,0 +1,163 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EventID returns the topic identifying an event, that is, the Keccak-256
+// hash of its canonical signature, for example
+// "Transfer(address,address,uint256)".  It is the first topic of the logs
+// of non-anonymous events.  As for MethodID, the signature is hashed as
+// given and so must be canonical.
+func EventID(signature string) [32]byte {
+	return Keccak256([]byte(signature))
+}
+
+// DecodeLog decodes the topics and data of an event log.  The first topic
+// identifies the event, see EventID, and is not decoded; the indexed
+// decoders are applied to the remaining topics, one 32-byte word each, and
+// the body decoders to the data with DecodeTuple.  Indexed arguments of
+// dynamic types, such as bytes or string, are stored in a topic as a
+// hash, see EncodeTopic, so their decoder should read the raw word, for
+// example with DecodeTupleFuncBytes32.
+func DecodeLog(
+	topics [][]byte,
+	data []byte,
+	indexed []DecoderFunc,
+	body []DecoderFunc,
+) error {
+	switch {
+	case len(topics) == 0:
+		return errors.New("log has no topics")
+	case len(topics)-1 != len(indexed):
+		format := "log has %d indexed topics but got %d decoders"
+		return fmt.Errorf(format, len(topics)-1, len(indexed))
+	}
+
+	for i, topic := range topics[1:] {
+		if len(topic) != 32 {
+			return fmt.Errorf("topic %d must contain 32 bytes", i+1)
+		}
+		if err := indexed[i](topic, topic); err != nil {
+			return fmt.Errorf("decoding topic %d: %w", i+1, err)
+		}
+	}
+
+	if len(body) == 0 {
+		if len(data) != 0 {
+			return errors.New("log has data but no body decoders")
+		}
+		return nil
+	}
+	if err := DecodeTuple(data, body...); err != nil {
+		return fmt.Errorf("decoding data: %w", err)
+	}
+	return nil
+}
+
+// EncodeTopic encodes v, a value of type t, as the topic of an indexed
+// event argument, for example, to build an eth_getLogs topic filter.
+// Values of elementary static types, such as uint256 or address, are
+// stored in a topic as their 32-byte encoding.  All other values are
+// stored as the Keccak-256 hash of their encoding: for bytes and string
+// that is the raw content, while for arrays and tuples it is the
+// concatenation of the padded encodings of their elements.
+func EncodeTopic(t Type, v any) ([32]byte, error) {
+	var topic [32]byte
+	if err := t.validate(); err != nil {
+		return topic, fmt.Errorf("invalid type: %w", err)
+	}
+
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind, ArrayKind, TupleKind:
+		data, err := topicEncoding(t, v, false)
+		if err != nil {
+			return topic, err
+		}
+		return Keccak256(data), nil
+	}
+
+	res, err := encodeType(t, v)
+	if err != nil {
+		return topic, err
+	}
+	copy(topic[:], res.data)
+	return topic, nil
+}
+
+// topicEncoding returns the encoding of v that is hashed to form a topic.
+// When padded is set, bytes and string values are padded to a multiple of
+// 32 bytes, as is the case for elements of arrays and tuples.
+func topicEncoding(t Type, v any, padded bool) ([]byte, error) {
+	switch t.Kind {
+	case BytesKind:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, typeMismatch(t, v)
+		}
+		return topicContent(b, padded)
+	case StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return nil, typeMismatch(t, v)
+		}
+		return topicContent([]byte(s), padded)
+	case SliceKind:
+		elems, ok := v.([]any)
+		if !ok {
+			return nil, typeMismatch(t, v)
+		}
+		return topicSequence(elems, func(int) Type { return *t.Elem })
+	case ArrayKind:
+		elems, ok := v.([]any)
+		switch {
+		case !ok:
+			return nil, typeMismatch(t, v)
+		case len(elems) != t.Size:
+			return nil, fmt.Errorf("%s value must contain %d elements", t, t.Size)
+		}
+		return topicSequence(elems, func(int) Type { return *t.Elem })
+	case TupleKind:
+		fields, ok := v.([]any)
+		switch {
+		case !ok:
+			return nil, typeMismatch(t, v)
+		case len(fields) != len(t.Components):
+			format := "%s value must contain %d fields"
+			return nil, fmt.Errorf(format, t, len(t.Components))
+		}
+		return topicSequence(fields, func(i int) Type { return t.Components[i] })
+	}
+
+	res, err := encodeType(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return res.data, nil
+}
+
+// topicSequence concatenates the padded topic encodings of values, where
+// typeAt gives the type of the i-th value.
+func topicSequence(values []any, typeAt func(i int) Type) ([]byte, error) {
+	out := []byte{}
+	for i := range values {
+		enc, err := topicEncoding(typeAt(i), values[i], true)
+		if err != nil {
+			return nil, fmt.Errorf("encoding element %d: %w", i, err)
+		}
+		out = append(out, enc...)
+	}
+	return out, nil
+}
+
+func topicContent(content []byte, padded bool) ([]byte, error) {
+	if !padded {
+		return content, nil
+	}
+	alignedLen, err := nextMultipleOf32(len(content))
+	if err != nil {
+		return nil, err
+	}
+	return padRight(content, alignedLen)
+}
@@ -0,0 +1,223 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEventID(t *testing.T) {
+	for _, tc := range []struct {
+		signature string
+		want      string
+	}{
+		{
+			signature: "Transfer(address,address,uint256)",
+			want:      "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		},
+		{
+			signature: "Approval(address,address,uint256)",
+			want:      "8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+		},
+	} {
+		t.Run(tc.signature, func(t *testing.T) {
+			// when
+			got := abi.EventID(tc.signature)
+			// then
+			assert.Equal(t, hexDecode(tc.want), got[:])
+		})
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	transferID := abi.EventID("Transfer(address,address,uint256)")
+	from, to := someAddress(), [20]byte{0x02}
+	fromTopic, toTopic := abi.EncodeAddress(from), abi.EncodeAddress(to)
+
+	t.Run("indexed topics and data", func(t *testing.T) {
+		// given
+		topics := [][]byte{transferID[:], fromTopic, toTopic}
+		data := abi.EncodeUint64(1000)
+		var gotFrom, gotTo [20]byte
+		var gotValue uint64
+		// when
+		err := abi.DecodeLog(
+			topics,
+			data,
+			[]abi.DecoderFunc{
+				abi.DecodeTupleFuncAddress(&gotFrom),
+				abi.DecodeTupleFuncAddress(&gotTo),
+			},
+			[]abi.DecoderFunc{abi.DecodeTupleFuncUint64(&gotValue)},
+		)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, from, gotFrom)
+		assert.Equal(t, to, gotTo)
+		assert.Equal(t, uint64(1000), gotValue)
+	})
+
+	t.Run("indexed dynamic arguments surface the hash", func(t *testing.T) {
+		// given
+		id := abi.EventID("Named(string,bytes)")
+		nameTopic, err := abi.EncodeTopic(abi.StringType(), "alice")
+		require.NoError(t, err)
+		data, err := abi.EncodeTuple(abi.EncodeTupleFuncBytes([]byte("payload")))
+		require.NoError(t, err)
+		var gotHash [32]byte
+		var gotPayload []byte
+		// when
+		err = abi.DecodeLog(
+			[][]byte{id[:], nameTopic[:]},
+			data,
+			[]abi.DecoderFunc{abi.DecodeTupleFuncBytes32(&gotHash)},
+			[]abi.DecoderFunc{abi.DecodeTupleFuncBytes(&gotPayload)},
+		)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, abi.Keccak256([]byte("alice")), gotHash)
+		assert.Equal(t, []byte("payload"), gotPayload)
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		// given
+		var gotFrom [20]byte
+		indexed := []abi.DecoderFunc{abi.DecodeTupleFuncAddress(&gotFrom)}
+		// when
+		err := abi.DecodeLog([][]byte{transferID[:], fromTopic}, nil, indexed, nil)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, from, gotFrom)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var addr [20]byte
+		var value uint64
+		indexed := []abi.DecoderFunc{abi.DecodeTupleFuncAddress(&addr)}
+		body := []abi.DecoderFunc{abi.DecodeTupleFuncUint64(&value)}
+
+		for _, tc := range []struct {
+			name    string
+			topics  [][]byte
+			data    []byte
+			body    []abi.DecoderFunc
+			wantErr string
+		}{
+			{
+				name:    "no topics",
+				wantErr: "log has no topics",
+			},
+			{
+				name:    "missing indexed topic",
+				topics:  [][]byte{transferID[:]},
+				wantErr: "log has 0 indexed topics but got 1 decoders",
+			},
+			{
+				name:    "short topic",
+				topics:  [][]byte{transferID[:], fromTopic[:31]},
+				wantErr: "topic 1 must contain 32 bytes",
+			},
+			{
+				name:    "invalid topic",
+				topics:  [][]byte{transferID[:], transferID[:]},
+				wantErr: "decoding topic 1",
+			},
+			{
+				name:    "data without body decoders",
+				topics:  [][]byte{transferID[:], fromTopic},
+				data:    abi.EncodeUint64(1),
+				wantErr: "log has data but no body decoders",
+			},
+			{
+				name:    "short data",
+				topics:  [][]byte{transferID[:], fromTopic},
+				data:    nZeros(31),
+				body:    body,
+				wantErr: "decoding data",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				err := abi.DecodeLog(tc.topics, tc.data, indexed, tc.body)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+func TestEncodeTopic(t *testing.T) {
+	t.Run("static types are stored as their encoding", func(t *testing.T) {
+		for _, tc := range []struct {
+			typ   string
+			value any
+			want  []byte
+		}{
+			{typ: "uint256", value: big.NewInt(42), want: abi.EncodeUint64(42)},
+			{typ: "address", value: someAddress(), want: abi.EncodeAddress(someAddress())},
+			{typ: "int8", value: -1, want: hexDecode("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+		} {
+			t.Run(tc.typ, func(t *testing.T) {
+				// when
+				got, err := abi.EncodeTopic(abi.MustParseType(tc.typ), tc.value)
+				require.NoError(t, err)
+				// then
+				assert.Equal(t, tc.want, got[:])
+			})
+		}
+	})
+
+	t.Run("bytes and string are stored as the hash of their content", func(t *testing.T) {
+		// given
+		want := abi.Keccak256([]byte("hello"))
+		// when
+		gotBytes, err := abi.EncodeTopic(abi.BytesType(), []byte("hello"))
+		require.NoError(t, err)
+		gotString, err := abi.EncodeTopic(abi.StringType(), "hello")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, gotBytes)
+		assert.Equal(t, want, gotString)
+	})
+
+	t.Run("arrays and tuples are stored as the hash of padded elements", func(t *testing.T) {
+		// given
+		padded := append([]byte("hello"), nZeros(27)...)
+		want := abi.Keccak256(append(abi.EncodeUint64(7), padded...))
+		value := []any{big.NewInt(7), "hello"}
+		// when
+		gotTuple, err := abi.EncodeTopic(abi.MustParseType("(uint256,string)"), value)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, gotTuple)
+	})
+
+	t.Run("slices are stored without a length", func(t *testing.T) {
+		// given
+		want := abi.Keccak256(append(abi.EncodeUint64(1), abi.EncodeUint64(2)...))
+		value := []any{big.NewInt(1), big.NewInt(2)}
+		// when
+		got, err := abi.EncodeTopic(abi.MustParseType("uint256[]"), value)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeTopic(abi.UintType(7), 1)
+		// then
+		assert.ErrorContains(t, err, "invalid type")
+	})
+
+	t.Run("wrong value", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeTopic(abi.StringType(), []byte("hello"))
+		// then
+		assert.ErrorContains(t, err, "cannot encode []uint8 as string")
+	})
+}
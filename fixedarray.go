@@ -0,0 +1,176 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// EncodeFixedUint64Array encodes v as a fixed-size array of len(v) uint64
+// values.  Unlike a slice, a fixed-size array of static elements has
+// neither a slice header nor an element count, its elements are simply
+// stored one after another.  It is the inverse operation of
+// DecodeFixedUint64ArrayInto.
+func EncodeFixedUint64Array(v []uint64) []byte {
+	out := make([]byte, 0, 32*len(v))
+	for i := range v {
+		out = append(out, EncodeUint64(v[i])...)
+	}
+	return out
+}
+
+// DecodeFixedUint64ArrayInto decodes a fixed-size array of len(dst) uint64
+// values into dst.  Passing a slice of a go array, such as arr[:] for an
+// arr of type [3]uint64, ties the expected length to the type of arr.  It
+// is the inverse operation of EncodeFixedUint64Array.
+func DecodeFixedUint64ArrayInto(abiEncoded []byte, dst []uint64) error {
+	switch {
+	case len(abiEncoded) == 0 && len(dst) > 0:
+		return ErrEmptyInput
+	case len(abiEncoded) != 32*len(dst):
+		format := "fixed array of %d elements must contain %d bytes"
+		return fmt.Errorf(format, len(dst), 32*len(dst))
+	}
+
+	for i := range dst {
+		v, err := DecodeUint64(abiEncoded[i*32 : (i+1)*32])
+		if err != nil {
+			return fmt.Errorf("decoding element %d, %w", i, err)
+		}
+		dst[i] = v
+	}
+	return nil
+}
+
+// EncodeTupleFuncFixedUint64Array encodes a fixed-size array of uint64
+// values as the k-th element of a tuple.  The array is static and so it is
+// stored inline, taking up one head word per element.
+func EncodeTupleFuncFixedUint64Array(v []uint64) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data := EncodeFixedUint64Array(v)
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncsFixedUint64Array returns the decoders for a fixed-size
+// array of len(dst) uint64 values that is an element of a tuple.  The array
+// is stored inline, one head word per element, and so there is one decoder
+// per element.  As for DecodeFixedUint64ArrayInto, dst may be a slice of a
+// go array, such as arr[:].
+func DecodeTupleFuncsFixedUint64Array(dst []uint64) []DecoderFunc {
+	decoders := make([]DecoderFunc, len(dst))
+	for i := range dst {
+		decoders[i] = DecodeTupleFuncUint64(&dst[i])
+	}
+	return decoders
+}
+
+// EncodeFixedArrayOfBytes32 encodes v as a bytes32[n], that is, as n
+// inline 32-byte words without a slice header or element count.  It is
+// common for fixed-length Merkle proofs.  It is the inverse operation of
+// DecodeFixedArrayOfBytes32.
+func EncodeFixedArrayOfBytes32(v [][32]byte, n int) ([]byte, error) {
+	if len(v) != n {
+		return nil, fmt.Errorf("fixed array of %d elements got %d", n, len(v))
+	}
+
+	out := make([]byte, 32*n)
+	for i := range v {
+		if err := WriteWord(out, i, v[i][:]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// DecodeFixedArrayOfBytes32 decodes a bytes32[n].  It is the inverse
+// operation of EncodeFixedArrayOfBytes32.
+func DecodeFixedArrayOfBytes32(abiEncoded []byte, n int) ([][32]byte, error) {
+	switch {
+	case len(abiEncoded) == 0 && n > 0:
+		return nil, ErrEmptyInput
+	case n > math.MaxInt/32:
+		// 32*n would overflow
+		return nil, fmt.Errorf("fixed array of %d elements out of range", n)
+	case n < 0 || len(abiEncoded) != 32*n:
+		format := "fixed array of %d elements must contain %d bytes"
+		return nil, fmt.Errorf(format, n, 32*n)
+	}
+
+	out := make([][32]byte, n)
+	for i := range out {
+		copy(out[i][:], abiEncoded[i*32:(i+1)*32])
+	}
+	return out, nil
+}
+
+// EncodeTupleFuncFixedBytes32Array encodes a bytes32[len(v)] as the k-th
+// element of a tuple.  The array is static and so it is stored inline,
+// taking up one head word per element.
+func EncodeTupleFuncFixedBytes32Array(v [][32]byte) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data, err := EncodeFixedArrayOfBytes32(v, len(v))
+		return EncoderResult{indirect: false, data: data}, err
+	}
+}
+
+// DecodeTupleFuncsFixedBytes32Array returns the decoders for a
+// bytes32[len(dst)] that is an element of a tuple.  The array is stored
+// inline, one head word per element, and so there is one decoder per
+// element.
+func DecodeTupleFuncsFixedBytes32Array(dst [][32]byte) []DecoderFunc {
+	decoders := make([]DecoderFunc, len(dst))
+	for i := range dst {
+		decoders[i] = func(cur, full []byte) error {
+			copy(dst[i][:], cur)
+			return nil
+		}
+	}
+	return decoders
+}
+
+// EncodeFixedArray encodes a fixed-size array of n elements, T[n], whose
+// elements are encoded by elements.  Unlike EncodeSlice, there is no slice
+// header or element count, as the length is part of the type, so the
+// elements are laid out like the fields of a tuple.  For example, a
+// uint256[4] is encoded as four inline words.  The ABI has no arrays of
+// zero elements, so n must be at least 1.  It is the inverse operation of
+// DecodeFixedArray.
+func EncodeFixedArray(n int, elements []EncoderFunc) ([]byte, error) {
+	switch {
+	case n < 1:
+		return nil, fmt.Errorf("invalid array length %d", n)
+	case len(elements) != n:
+		return nil, fmt.Errorf("fixed array of %d elements got %d", n, len(elements))
+	}
+
+	results, err := runEncoders(elements)
+	if err != nil {
+		return nil, err
+	}
+	return assembleTuple(results)
+}
+
+// DecodeFixedArray decodes a fixed-size array of n elements, calling
+// makeDecoder for the index of each element to get the decoder of that
+// element.  As for DecodeSlice, each element must take up a single head
+// slot, and as for EncodeFixedArray, n must be at least 1.  It is the
+// inverse operation of EncodeFixedArray.
+func DecodeFixedArray(data []byte, n int, makeDecoder func(i int) DecoderFunc) error {
+	// the length is checked against the data before the decoders are
+	// made, so that a huge n does not allocate
+	switch {
+	case n < 1:
+		return fmt.Errorf("invalid array length %d", n)
+	case len(data) == 0:
+		return ErrEmptyInput
+	case n > len(data)/32:
+		return errors.New("not long enough to support all decoders")
+	}
+
+	decoders := make([]DecoderFunc, n)
+	for i := range decoders {
+		decoders[i] = makeDecoder(i)
+	}
+	return DecodeTuple(data, decoders...)
+}
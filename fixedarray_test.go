@@ -0,0 +1,299 @@
+package abi_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeFixedUint64Array(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := [3]uint64{1, 2, 3}
+		want := append(abi.EncodeUint64(1), abi.EncodeUint64(2)...)
+		want = append(want, abi.EncodeUint64(3)...)
+		// when
+		got := abi.EncodeFixedUint64Array(input[:])
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDecodeFixedUint64ArrayInto(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := [3]uint64{1, 2, 3}
+		input := abi.EncodeFixedUint64Array(want[:])
+		// when
+		var got [3]uint64
+		err := abi.DecodeFixedUint64ArrayInto(input, got[:])
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("length does not match destination", func(t *testing.T) {
+		// given
+		input := abi.EncodeFixedUint64Array([]uint64{1, 2, 3})
+		// when
+		var got [2]uint64
+		err := abi.DecodeFixedUint64ArrayInto(input, got[:])
+		// then
+		assert.ErrorContains(t, err, "fixed array of 2 elements must contain 64 bytes")
+	})
+
+	t.Run("bad element", func(t *testing.T) {
+		// given
+		input := abi.EncodeFixedUint64Array([]uint64{1, 2, 3})
+		input[32] = 1
+		// when
+		var got [3]uint64
+		err := abi.DecodeFixedUint64ArrayInto(input, got[:])
+		// then
+		assert.ErrorContains(t, err, "decoding element 1")
+	})
+}
+
+func TestTupleEncoderDecoder_FixedUint64Array(t *testing.T) {
+	t.Run("array is stored inline", func(t *testing.T) {
+		// given
+		array := [3]uint64{1, 2, 3}
+		want, err := abi.NewTupleEncoder().
+			Uint64(1).
+			Uint64(2).
+			Uint64(3).
+			Bytes([]byte("tail")).
+			Uint64(4).
+			Encode()
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.NewTupleEncoder().
+			FixedUint64Array(array[:]).
+			Bytes([]byte("tail")).
+			Uint64(4).
+			Encode()
+		require.NoError(t, err)
+
+		var gotArray [3]uint64
+		var gotBytes []byte
+		var gotUint64 uint64
+		err = abi.NewTupleDecoder().
+			FixedUint64Array(gotArray[:]).
+			Bytes(&gotBytes).
+			Uint64(&gotUint64).
+			Decode(encoded)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, array, gotArray)
+		assert.Equal(t, []byte("tail"), gotBytes)
+		assert.Equal(t, uint64(4), gotUint64)
+	})
+}
+
+func someBytes32s(n int) [][32]byte {
+	v := make([][32]byte, n)
+	for i := range v {
+		for j := range v[i] {
+			v[i][j] = byte(16*i + j)
+		}
+	}
+	return v
+}
+
+func TestEncodeDecodeFixedArrayOfBytes32RoundTrip(t *testing.T) {
+	for _, n := range []int{2, 3} {
+		t.Run(fmt.Sprintf("bytes32[%d]", n), func(t *testing.T) {
+			// given
+			input := someBytes32s(n)
+			values := make([]any, n)
+			for i := range input {
+				values[i] = input[i][:]
+			}
+			want, err := abi.EncodeValue(abi.ArrayType(abi.FixedBytesType(32), n), values)
+			require.NoError(t, err)
+
+			// when
+			encoded, err := abi.EncodeFixedArrayOfBytes32(input, n)
+			require.NoError(t, err)
+			require.Equal(t, want, encoded)
+
+			got, err := abi.DecodeFixedArrayOfBytes32(encoded, n)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+}
+
+func TestEncodeFixedArrayOfBytes32(t *testing.T) {
+	t.Run("wrong number of elements", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeFixedArrayOfBytes32(someBytes32s(2), 3)
+		// then
+		assert.ErrorContains(t, err, "fixed array of 3 elements got 2")
+	})
+}
+
+func TestDecodeFixedArrayOfBytes32(t *testing.T) {
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeFixedArrayOfBytes32(nZeros(64), 3)
+		// then
+		assert.ErrorContains(t, err, "fixed array of 3 elements must contain 96 bytes")
+	})
+
+	t.Run("length whose size overflows", func(t *testing.T) {
+		// given 32*n wraps around to 32
+		n := math.MaxInt/16 + 2
+		// when
+		var err error
+		require.NotPanics(t, func() { _, err = abi.DecodeFixedArrayOfBytes32(nZeros(32), n) })
+		// then
+		assert.ErrorContains(t, err, "out of range")
+	})
+}
+
+func TestTupleEncoderDecoder_FixedBytes32Array(t *testing.T) {
+	// given
+	proof := someBytes32s(3)
+	want, err := abi.Encode(
+		[]abi.Type{abi.UintType(64), abi.ArrayType(abi.FixedBytesType(32), 3), abi.BytesType()},
+		[]any{1, []any{proof[0][:], proof[1][:], proof[2][:]}, []byte("tail")},
+	)
+	require.NoError(t, err)
+
+	// when
+	encoded, err := abi.NewTupleEncoder().
+		Uint64(1).
+		FixedBytes32Array(proof).
+		Bytes([]byte("tail")).
+		Encode()
+	require.NoError(t, err)
+
+	var gotUint64 uint64
+	gotProof := make([][32]byte, 3)
+	var gotBytes []byte
+	err = abi.NewTupleDecoder().
+		Uint64(&gotUint64).
+		FixedBytes32Array(gotProof).
+		Bytes(&gotBytes).
+		Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, want, encoded)
+	assert.Equal(t, uint64(1), gotUint64)
+	assert.Equal(t, proof, gotProof)
+	assert.Equal(t, []byte("tail"), gotBytes)
+}
+
+func TestEncodeDecodeFixedArray(t *testing.T) {
+	t.Run("uint256[4]", func(t *testing.T) {
+		// given
+		input := []uint64{1, 2, 3, math.MaxUint64}
+		elements := make([]abi.EncoderFunc, len(input))
+		for i := range input {
+			elements[i] = abi.EncodeTupleFuncUint64(input[i])
+		}
+		want, err := abi.EncodeValue(
+			abi.ArrayType(abi.UintType(256), 4),
+			[]any{input[0], input[1], input[2], input[3]},
+		)
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.EncodeFixedArray(4, elements)
+		require.NoError(t, err)
+
+		got := make([]uint64, 4)
+		err = abi.DecodeFixedArray(encoded, 4, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncUint64(&got[i])
+		})
+		require.NoError(t, err)
+
+		// then
+		// there is no slice header or count, just the four words
+		assert.Equal(t, want, encoded)
+		assert.Len(t, encoded, 4*32)
+		assert.Equal(t, input, got)
+	})
+
+	t.Run("bytes[2]", func(t *testing.T) {
+		// given
+		input := [][]byte{[]byte("abc"), {}}
+		elements := []abi.EncoderFunc{
+			abi.EncodeTupleFuncBytes(input[0]),
+			abi.EncodeTupleFuncBytes(input[1]),
+		}
+		// the array is dynamic, so as a value it is preceded by its offset
+		wrapped, err := abi.EncodeValue(
+			abi.ArrayType(abi.BytesType(), 2),
+			[]any{input[0], input[1]},
+		)
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.EncodeFixedArray(2, elements)
+		require.NoError(t, err)
+
+		got := make([][]byte, 2)
+		err = abi.DecodeFixedArray(encoded, 2, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncBytes(&got[i])
+		})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, wrapped[32:], encoded)
+		assert.Equal(t, input, got)
+	})
+
+	t.Run("wrong element count", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeFixedArray(4, []abi.EncoderFunc{abi.EncodeTupleFuncUint64(1)})
+		// then
+		assert.ErrorContains(t, err, "fixed array of 4 elements got 1")
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		// given
+		got := make([]uint64, 4)
+		// when
+		err := abi.DecodeFixedArray(nZeros(3*32), 4, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncUint64(&got[i])
+		})
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all decoders")
+	})
+
+	t.Run("huge length is rejected before making decoders", func(t *testing.T) {
+		// given
+		made := 0
+		makeDecoder := func(int) abi.DecoderFunc {
+			made++
+			return abi.DecodeTupleFuncUint64(new(uint64))
+		}
+		// when
+		err := abi.DecodeFixedArray(nZeros(64), math.MaxInt, makeDecoder)
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all decoders")
+		assert.Zero(t, made)
+	})
+
+	t.Run("zero length", func(t *testing.T) {
+		// when
+		_, encodeErr := abi.EncodeFixedArray(0, nil)
+		decodeErr := abi.DecodeFixedArray(nZeros(32), 0, nil)
+		// then
+		assert.ErrorContains(t, encodeErr, "invalid array length 0")
+		assert.ErrorContains(t, decodeErr, "invalid array length 0")
+	})
+}
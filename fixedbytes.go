@@ -0,0 +1,85 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EncodeFixedBytes encodes v as a bytes<n>, that is, right padded with
+// zeros to a single 32-byte word.  Unlike EncodeBytes, there is no length
+// word, as the length is part of the type.  A v shorter than n is padded
+// as if it had trailing zeros.  It is the inverse operation of
+// DecodeFixedBytes.
+func EncodeFixedBytes(v []byte, n int) ([]byte, error) {
+	switch {
+	case n < 1 || n > 32:
+		return nil, fmt.Errorf("invalid fixed bytes size %d", n)
+	case len(v) > n:
+		return nil, fmt.Errorf("value of %d bytes does not fit in bytes%d", len(v), n)
+	}
+	return padRight(v, 32)
+}
+
+// DecodeFixedBytes decodes a bytes<n>, checking that the 32-n bytes of
+// padding that follow the value are zero.  It is the inverse operation of
+// EncodeFixedBytes.
+func DecodeFixedBytes(v []byte, n int) ([]byte, error) {
+	switch {
+	case n < 1 || n > 32:
+		return nil, fmt.Errorf("invalid fixed bytes size %d", n)
+	case len(v) == 0:
+		return nil, ErrEmptyInput
+	case len(v) != 32:
+		return nil, errors.New("fixed bytes encoding must contain 32 bytes")
+	}
+	return decodeFixedBytes(v, n)
+}
+
+// EncodeTupleFuncFixedBytes encodes a bytes<n> as the k-th element of a
+// tuple.  Unlike EncodeTupleFuncBytes, the value is static and so it is
+// stored inline rather than referenced by an offset.
+func EncodeTupleFuncFixedBytes(v []byte, n int) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data, err := EncodeFixedBytes(v, n)
+		if err != nil {
+			return EncoderResult{}, fmt.Errorf("encoding: %w", err)
+		}
+
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncFixedBytes decodes a bytes<n> as the k-th element of a
+// tuple.  The value is read from its head slot, as it is static.
+func DecodeTupleFuncFixedBytes(v *[]byte, n int) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := DecodeFixedBytes(cur[:32], n)
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		*v = vv
+		return nil
+	}
+}
+
+// EncodeTupleFuncBytes32 encodes a bytes32 as the k-th element of a tuple.
+func EncodeTupleFuncBytes32(v [32]byte) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data := make([]byte, 32)
+		copy(data, v[:])
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncBytes32 decodes a bytes32 as the k-th element of a tuple.
+func DecodeTupleFuncBytes32(v *[32]byte) DecoderFunc {
+	return func(cur, full []byte) error {
+		if len(cur) < 32 {
+			return errors.New("decoding: not long enough to hold a word")
+		}
+
+		copy(v[:], cur[:32])
+		return nil
+	}
+}
@@ -0,0 +1,173 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeFixedBytes(t *testing.T) {
+	t.Run("right padded", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeFixedBytes([]byte{0xde, 0xad, 0xbe, 0xef}, 4)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, append([]byte{0xde, 0xad, 0xbe, 0xef}, nZeros(28)...), got)
+	})
+
+	t.Run("shorter than size", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeFixedBytes([]byte{0x01}, 8)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, append([]byte{0x01}, nZeros(31)...), got)
+	})
+
+	t.Run("matches schema encoding", func(t *testing.T) {
+		// given
+		v := someBytes32s(1)[0]
+		want, err := abi.EncodeValue(abi.FixedBytesType(32), v[:])
+		require.NoError(t, err)
+		// when
+		got, err := abi.EncodeFixedBytes(v[:], 32)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeFixedBytes(nZeros(5), 4)
+		// then
+		assert.ErrorContains(t, err, "value of 5 bytes does not fit in bytes4")
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		for _, n := range []int{0, 33} {
+			// when
+			_, err := abi.EncodeFixedBytes(nil, n)
+			// then
+			assert.ErrorContains(t, err, "invalid fixed bytes size")
+		}
+	})
+}
+
+func TestDecodeFixedBytes(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		want := []byte{0xde, 0xad, 0xbe, 0xef}
+		encoded, err := abi.EncodeFixedBytes(want, 4)
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeFixedBytes(encoded, 4)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("non-zero padding", func(t *testing.T) {
+		// given
+		encoded, err := abi.EncodeFixedBytes([]byte{1, 2, 3, 4}, 4)
+		require.NoError(t, err)
+		encoded[4] = 1
+		// when
+		_, err = abi.DecodeFixedBytes(encoded, 4)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeFixedBytes(nZeros(4), 4)
+		// then
+		assert.ErrorContains(t, err, "fixed bytes encoding must contain 32 bytes")
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeFixedBytes(nZeros(32), 33)
+		// then
+		assert.ErrorContains(t, err, "invalid fixed bytes size 33")
+	})
+}
+
+func TestTupleEncoderDecoder_Bytes32(t *testing.T) {
+	// given
+	hash := someBytes32s(1)[0]
+	encoded, err := abi.NewTupleEncoder().Bytes32(hash).Bytes([]byte("abc")).Encode()
+	require.NoError(t, err)
+
+	// when
+	var gotHash [32]byte
+	var gotBytes []byte
+	err = abi.NewTupleDecoder().Bytes32(&gotHash).Bytes(&gotBytes).Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	// the bytes32 is stored inline, so the offset of the bytes follows it
+	want, err := abi.Encode(
+		[]abi.Type{abi.FixedBytesType(32), abi.BytesType()},
+		[]any{hash[:], []byte("abc")},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, encoded)
+	assert.Equal(t, hash, gotHash)
+	assert.Equal(t, []byte("abc"), gotBytes)
+}
+
+func TestEncodeDecodeTupleFuncFixedBytes(t *testing.T) {
+	t.Run("static element", func(t *testing.T) {
+		// given
+		selector := []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+		// when
+		encoded, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncFixedBytes(selector, 4),
+			abi.EncodeTupleFuncBytes([]byte("abc")),
+			abi.EncodeTupleFuncUint64(9),
+		)
+		require.NoError(t, err)
+
+		var gotSelector, gotBytes []byte
+		var gotUint uint64
+		err = abi.DecodeTuple(encoded,
+			abi.DecodeTupleFuncFixedBytes(&gotSelector, 4),
+			abi.DecodeTupleFuncBytes(&gotBytes),
+			abi.DecodeTupleFuncUint64(&gotUint),
+		)
+		require.NoError(t, err)
+
+		// then
+		// a static element leaves the offset of the bytes at 3 words
+		want, err := abi.Encode(
+			[]abi.Type{abi.FixedBytesType(4), abi.BytesType(), abi.UintType(64)},
+			[]any{selector, []byte("abc"), 9},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, abi.EncodeUint64(3*32), encoded[32:64])
+		assert.Equal(t, selector, gotSelector)
+		assert.Equal(t, []byte("abc"), gotBytes)
+		assert.Equal(t, uint64(9), gotUint)
+	})
+
+	t.Run("encoding error", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeTuple(abi.EncodeTupleFuncFixedBytes(nZeros(33), 32))
+		// then
+		assert.ErrorContains(t, err, "does not fit in bytes32")
+	})
+
+	t.Run("decoding error", func(t *testing.T) {
+		// given
+		encoded := abi.EncodeUint64(1)
+		// when
+		var got []byte
+		err := abi.DecodeTuple(encoded, abi.DecodeTupleFuncFixedBytes(&got, 4))
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
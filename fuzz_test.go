@@ -0,0 +1,184 @@
+package abi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blocky/abi"
+)
+
+// schemaGen builds a random schema from the bytes of a fuzz input.  Once
+// the bytes run out, it produces zeros, so generation always terminates.
+type schemaGen struct {
+	data []byte
+}
+
+func (g *schemaGen) next() byte {
+	if len(g.data) == 0 {
+		return 0
+	}
+	b := g.data[0]
+	g.data = g.data[1:]
+	return b
+}
+
+func (g *schemaGen) typ(depth int) abi.Type {
+	kind := g.next() % 10
+	if depth >= 3 {
+		kind %= 7 // only elementary types
+	}
+
+	switch kind {
+	case 0:
+		return abi.UintType(8 * (1 + int(g.next()%32)))
+	case 1:
+		return abi.IntType(8 * (1 + int(g.next()%32)))
+	case 2:
+		return abi.BoolType()
+	case 3:
+		return abi.AddressType()
+	case 4:
+		return abi.FixedBytesType(1 + int(g.next()%32))
+	case 5:
+		return abi.BytesType()
+	case 6:
+		return abi.StringType()
+	case 7:
+		return abi.SliceType(g.typ(depth + 1))
+	case 8:
+		return abi.ArrayType(g.typ(depth+1), 1+int(g.next()%4))
+	default:
+		return abi.TupleType(g.types(depth + 1)...)
+	}
+}
+
+func (g *schemaGen) types(depth int) []abi.Type {
+	types := make([]abi.Type, 1+g.next()%4)
+	for i := range types {
+		types[i] = g.typ(depth)
+	}
+	return types
+}
+
+func FuzzDecodeTupleSchema(f *testing.F) {
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{5, 7, 5}, hexDecode(""+
+		"0000000000000000000000000000000000000000000000000000000000000020"+
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000000000020"+
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	))
+	f.Add([]byte{2, 9, 1, 7, 3, 5, 8, 0, 0}, mixedTypes.encoded)
+	f.Add([]byte{0, 7, 9, 1, 9, 1, 7, 4, 31, 0, 0, 3}, orders.encoded)
+
+	f.Fuzz(func(t *testing.T, schemaSeed []byte, data []byte) {
+		g := &schemaGen{data: schemaSeed}
+		schema := g.types(0)
+
+		// decoding must never panic, whatever the input
+		values, err := abi.Decode(data, schema, abi.DecodeOptions{})
+		if err != nil {
+			return
+		}
+
+		// whatever decodes must encode again and decode to the same values
+		encoded, err := abi.Encode(schema, values)
+		if err != nil {
+			t.Fatalf("encoding decoded values of %v: %v", schema, err)
+		}
+		ok, err := abi.TupleEqual(data, encoded, schema)
+		if err != nil || !ok {
+			t.Fatalf("re-encoding of %v differs: %v", schema, err)
+		}
+	})
+}
+
+func FuzzDecodeBytes(f *testing.F) {
+	f.Add([]byte{})
+	for _, v := range [][]byte{{}, []byte("hello"), make([]byte, 32), make([]byte, 33)} {
+		encoded, err := abi.EncodeBytes(v)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(encoded)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// decoding must never panic, whatever the input
+		got, err := abi.DecodeBytes(data)
+		if validateErr := abi.ValidateBytesEncoding(data); (err == nil) != (validateErr == nil) {
+			t.Fatalf("decoding error %v but validation error %v", err, validateErr)
+		}
+		if err != nil {
+			return
+		}
+
+		// the encoding of bytes is canonical, so it must encode to data
+		encoded, err := abi.EncodeBytes(got)
+		if err != nil {
+			t.Fatalf("encoding decoded bytes: %v", err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("re-encoding differs: %x", encoded)
+		}
+	})
+}
+
+func FuzzDecodeSliceOfBytes(f *testing.F) {
+	f.Add([]byte{})
+	for _, tc := range testData.sliceOfBytes {
+		f.Add(tc.encoded)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// decoding must never panic, whatever the input
+		got, err := abi.DecodeSliceOfBytes(data)
+		if validateErr := abi.ValidateSliceOfBytesEncoding(data); (err == nil) != (validateErr == nil) {
+			t.Fatalf("decoding error %v but validation error %v", err, validateErr)
+		}
+
+		var ranged [][]byte
+		rangeErr := abi.RangeSliceOfBytes(data, func(_ int, elem []byte) bool {
+			ranged = append(ranged, elem)
+			return true
+		})
+		if (err == nil) != (rangeErr == nil) {
+			t.Fatalf("decoding error %v but range error %v", err, rangeErr)
+		}
+		if err != nil {
+			return
+		}
+
+		// whatever decodes must encode again and decode to the same values
+		if len(ranged) != len(got) {
+			t.Fatalf("ranged over %d elements but decoded %d", len(ranged), len(got))
+		}
+		for i := range got {
+			if !bytes.Equal(ranged[i], got[i]) {
+				t.Fatalf("element %d ranged as %x but decoded as %x", i, ranged[i], got[i])
+			}
+		}
+		encoded, err := abi.EncodeSliceOfBytes(got)
+		if err != nil {
+			t.Fatalf("encoding decoded slice: %v", err)
+		}
+		again, err := abi.DecodeSliceOfBytes(encoded)
+		if err != nil {
+			t.Fatalf("decoding re-encoded slice: %v", err)
+		}
+		if len(again) != len(got) {
+			t.Fatalf("re-decoded %d elements but decoded %d", len(again), len(got))
+		}
+		for i := range got {
+			if !bytes.Equal(again[i], got[i]) {
+				t.Fatalf("element %d re-decoded as %x but decoded as %x", i, again[i], got[i])
+			}
+		}
+
+		// the strict decoder accepts exactly the canonical encodings
+		_, strictErr := abi.DecodeSliceOfBytesStrict(data)
+		if canonical := bytes.Equal(encoded, data); canonical != (strictErr == nil) {
+			t.Fatalf("canonical %v but strict decoding error %v", canonical, strictErr)
+		}
+	})
+}
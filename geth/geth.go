@@ -0,0 +1,85 @@
+// Package geth converts between the address and hash types of go-ethereum
+// and the fixed-size arrays used by package abi, and decodes ABI data
+// directly into the go-ethereum types.  It is a module of its own, so
+// that the core package does not depend on go-ethereum.
+package geth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/blocky/abi"
+)
+
+// FromAddress converts a go-ethereum address to the [20]byte used by abi.
+func FromAddress(addr common.Address) [20]byte {
+	return addr
+}
+
+// ToAddress converts a [20]byte used by abi to a go-ethereum address.
+func ToAddress(addr [20]byte) common.Address {
+	return addr
+}
+
+// FromHash converts a go-ethereum hash to the [32]byte used by abi.
+func FromHash(hash common.Hash) [32]byte {
+	return hash
+}
+
+// ToHash converts a [32]byte used by abi to a go-ethereum hash.
+func ToHash(hash [32]byte) common.Hash {
+	return hash
+}
+
+// EncodeAddressGeth encodes a go-ethereum address to 32-byte ABI format.
+// It is the inverse operation of DecodeAddressGeth.
+func EncodeAddressGeth(addr common.Address) []byte {
+	return abi.EncodeAddress(addr)
+}
+
+// DecodeAddressGeth decodes ABI bytes to a go-ethereum address.  It is the
+// inverse operation of EncodeAddressGeth.
+func DecodeAddressGeth(v []byte) (common.Address, error) {
+	return abi.DecodeAddress(v)
+}
+
+// EncodeHashGeth encodes a go-ethereum hash as an ABI bytes32.  It is the
+// inverse operation of DecodeHashGeth.
+func EncodeHashGeth(hash common.Hash) []byte {
+	// a hash always fills exactly one word, so encoding cannot fail
+	out, _ := abi.EncodeFixedBytes(hash[:], common.HashLength)
+	return out
+}
+
+// DecodeHashGeth decodes an ABI bytes32 to a go-ethereum hash.  It is the
+// inverse operation of EncodeHashGeth.
+func DecodeHashGeth(v []byte) (common.Hash, error) {
+	data, err := abi.DecodeFixedBytes(v, common.HashLength)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(data), nil
+}
+
+// EncodeTupleFuncAddressGeth encodes a go-ethereum address as the k-th
+// element of a tuple.
+func EncodeTupleFuncAddressGeth(addr common.Address) abi.EncoderFunc {
+	return abi.EncodeTupleFuncAddress(addr)
+}
+
+// DecodeTupleFuncAddressGeth decodes the k-th element of a tuple into a
+// go-ethereum address.
+func DecodeTupleFuncAddressGeth(v *common.Address) abi.DecoderFunc {
+	return abi.DecodeTupleFuncAddress((*[20]byte)(v))
+}
+
+// EncodeTupleFuncHashGeth encodes a go-ethereum hash as the k-th element
+// of a tuple.
+func EncodeTupleFuncHashGeth(hash common.Hash) abi.EncoderFunc {
+	return abi.EncodeTupleFuncBytes32(hash)
+}
+
+// DecodeTupleFuncHashGeth decodes the k-th element of a tuple into a
+// go-ethereum hash.
+func DecodeTupleFuncHashGeth(v *common.Hash) abi.DecoderFunc {
+	return abi.DecodeTupleFuncBytes32((*[32]byte)(v))
+}
@@ -0,0 +1,153 @@
+package geth_test
+
+import (
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+	"github.com/blocky/abi/geth"
+)
+
+var (
+	someAddress = common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4")
+	someHash    = common.HexToHash(
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+	)
+)
+
+func gethPack(t *testing.T, typ string, v any) []byte {
+	t.Helper()
+	abiType, err := gethabi.NewType(typ, "", nil)
+	require.NoError(t, err)
+	out, err := gethabi.Arguments{{Type: abiType}}.Pack(v)
+	require.NoError(t, err)
+	return out
+}
+
+func TestAddressConversion(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// when
+		got := geth.ToAddress(geth.FromAddress(someAddress))
+		// then
+		assert.Equal(t, someAddress, got)
+		assert.Equal(t, [20]byte(someAddress.Bytes()), geth.FromAddress(someAddress))
+	})
+}
+
+func TestHashConversion(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// when
+		got := geth.ToHash(geth.FromHash(someHash))
+		// then
+		assert.Equal(t, someHash, got)
+		assert.Equal(t, [32]byte(someHash.Bytes()), geth.FromHash(someHash))
+	})
+}
+
+func TestEncodeAddressGeth(t *testing.T) {
+	t.Run("matches go-ethereum", func(t *testing.T) {
+		// given
+		want := gethPack(t, "address", someAddress)
+		// when
+		got := geth.EncodeAddressGeth(someAddress)
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDecodeAddressGeth(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := gethPack(t, "address", someAddress)
+		// when
+		got, err := geth.DecodeAddressGeth(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, someAddress, got)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		// when
+		_, err := geth.DecodeAddressGeth(nil)
+		// then
+		assert.ErrorIs(t, err, abi.ErrEmptyInput)
+	})
+
+	t.Run("non-zero padding", func(t *testing.T) {
+		// given
+		input := gethPack(t, "address", someAddress)
+		input[0] = 0x01
+		// when
+		_, err := geth.DecodeAddressGeth(input)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
+
+func TestEncodeHashGeth(t *testing.T) {
+	t.Run("matches go-ethereum", func(t *testing.T) {
+		// given
+		want := gethPack(t, "bytes32", [32]byte(someHash))
+		// when
+		got := geth.EncodeHashGeth(someHash)
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDecodeHashGeth(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := gethPack(t, "bytes32", [32]byte(someHash))
+		// when
+		got, err := geth.DecodeHashGeth(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, someHash, got)
+	})
+
+	t.Run("not 32 bytes", func(t *testing.T) {
+		// when
+		_, err := geth.DecodeHashGeth(someHash[:31])
+		// then
+		assert.ErrorContains(t, err, "must contain 32 bytes")
+	})
+}
+
+func TestTupleFuncsGeth(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		addrType, err := gethabi.NewType("address", "", nil)
+		require.NoError(t, err)
+		hashType, err := gethabi.NewType("bytes32", "", nil)
+		require.NoError(t, err)
+		want, err := gethabi.Arguments{{Type: addrType}, {Type: hashType}}.
+			Pack(someAddress, [32]byte(someHash))
+		require.NoError(t, err)
+
+		// when
+		encoded, err := abi.EncodeTuple(
+			geth.EncodeTupleFuncAddressGeth(someAddress),
+			geth.EncodeTupleFuncHashGeth(someHash),
+		)
+		require.NoError(t, err)
+
+		var gotAddress common.Address
+		var gotHash common.Hash
+		err = abi.DecodeTuple(
+			encoded,
+			geth.DecodeTupleFuncAddressGeth(&gotAddress),
+			geth.DecodeTupleFuncHashGeth(&gotHash),
+		)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, encoded)
+		assert.Equal(t, someAddress, gotAddress)
+		assert.Equal(t, someHash, gotHash)
+	})
+}
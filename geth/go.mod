@@ -0,0 +1,21 @@
+module github.com/blocky/abi/geth
+
+go 1.24.6
+
+require (
+	github.com/blocky/abi v0.0.0-00010101000000-000000000000
+	github.com/ethereum/go-ethereum v1.14.12
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
+	github.com/holiman/uint256 v1.3.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.45.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/blocky/abi => ../
@@ -0,0 +1,42 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EncodeHex returns data as a 0x-prefixed lowercase hex string, the
+// format of byte values in the Ethereum JSON-RPC API.
+func EncodeHex(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
+
+// DecodeHex decodes a hex string, as returned by EncodeHex.  The 0x or 0X
+// prefix is optional, and both lowercase and uppercase digits are
+// accepted.
+func DecodeHex(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("hex string has odd length %d", len(s))
+	}
+
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeTupleHex decodes a tuple of elements from a hex string, as
+// returned by the Ethereum JSON-RPC API.  It combines DecodeHex and
+// DecodeTuple.
+func DecodeTupleHex(s string, decoders ...DecoderFunc) error {
+	data, err := DecodeHex(s)
+	if err != nil {
+		return err
+	}
+	return DecodeTuple(data, decoders...)
+}
@@ -0,0 +1,113 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeHex(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "nil", data: nil, want: "0x"},
+		{name: "lowercase", data: []byte{0x00, 0xab, 0xCD}, want: "0x00abcd"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got := abi.EncodeHex(tc.data)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		for _, tc := range []struct {
+			name  string
+			input string
+			want  []byte
+		}{
+			{name: "empty", input: "", want: []byte{}},
+			{name: "prefix only", input: "0x", want: []byte{}},
+			{name: "lowercase prefix", input: "0x00abcd", want: []byte{0x00, 0xab, 0xcd}},
+			{name: "uppercase prefix", input: "0X00ABCD", want: []byte{0x00, 0xab, 0xcd}},
+			{name: "no prefix", input: "00aBcD", want: []byte{0x00, 0xab, 0xcd}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				got, err := abi.DecodeHex(tc.input)
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			})
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		data := abi.EncodeUint64(42)
+		// when
+		got, err := abi.DecodeHex(abi.EncodeHex(data))
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			input   string
+			wantErr string
+		}{
+			{name: "odd length", input: "0xabc", wantErr: "hex string has odd length 3"},
+			{name: "invalid character", input: "0xzz", wantErr: "decoding hex"},
+			{name: "double prefix", input: "0x0x", wantErr: "decoding hex"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				_, err := abi.DecodeHex(tc.input)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+func TestDecodeTupleHex(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		data, err := abi.EncodeTuple(
+			abi.EncodeTupleFuncUint64(7),
+			abi.EncodeTupleFuncBytes([]byte("hello")),
+		)
+		require.NoError(t, err)
+		var gotUint uint64
+		var gotBytes []byte
+		// when
+		err = abi.DecodeTupleHex(
+			abi.EncodeHex(data),
+			abi.DecodeTupleFuncUint64(&gotUint),
+			abi.DecodeTupleFuncBytes(&gotBytes),
+		)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), gotUint)
+		assert.Equal(t, []byte("hello"), gotBytes)
+	})
+
+	t.Run("invalid hex", func(t *testing.T) {
+		// given
+		var v uint64
+		// when
+		err := abi.DecodeTupleHex("0xabc", abi.DecodeTupleFuncUint64(&v))
+		// then
+		assert.ErrorContains(t, err, "odd length")
+	})
+}
@@ -0,0 +1,139 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind tells whether an element of a tuple is stored inline in the head
+// or in the tail, referenced by an offset in the head.
+type Kind int
+
+// The kinds of tuple elements.
+const (
+	// Static elements are stored inline in a single head slot.
+	Static Kind = iota
+	// Dynamic elements are stored in the tail and referenced by an offset.
+	Dynamic
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Static:
+		return "static"
+	case Dynamic:
+		return "dynamic"
+	}
+	return fmt.Sprintf("kind(%d)", int(k))
+}
+
+// ElementLayout describes where an element of a tuple is stored.
+type ElementLayout struct {
+	Kind Kind
+	// HeadStart is the position in the data of the head slot of the
+	// element, which holds either its value or its offset.
+	HeadStart int
+	// Offset is the offset of a dynamic element, it is zero for a static
+	// element.
+	Offset uint64
+	// TailStart and TailEnd delimit the region of a dynamic element, which
+	// ends where the next region starts or at the end of the data.  They
+	// are zero for a static element.
+	TailStart int
+	TailEnd   int
+	// Length is the first word of the region of a dynamic element, which
+	// for bytes and string is the length and for slices is the element
+	// count.  It is zero for a static element and when the region does
+	// not start with a valid uint64.
+	Length uint64
+}
+
+// String describes the layout of the element.
+func (e ElementLayout) String() string {
+	if e.Kind == Static {
+		return fmt.Sprintf("static at 0x%x", e.HeadStart)
+	}
+	format := "dynamic at offset 0x%x, length %d, tail [0x%x, 0x%x)"
+	return fmt.Sprintf(format, e.Offset, e.Length, e.TailStart, e.TailEnd)
+}
+
+// TupleLayout describes how a tuple is laid out in its encoding.
+type TupleLayout struct {
+	// HeadLen is the length of the head, in bytes.
+	HeadLen  int
+	Elements []ElementLayout
+}
+
+// String describes the layout of the tuple, an element per line.
+func (l TupleLayout) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "head of %d bytes", l.HeadLen)
+	for i := range l.Elements {
+		fmt.Fprintf(&sb, "\nelement %d is %s", i, l.Elements[i])
+	}
+	return sb.String()
+}
+
+// InspectTuple reports how the tuple in data, whose elements are of the
+// given kinds, is laid out, without decoding the elements.  It is intended
+// for diagnosing malformed data, so only the offsets are validated, that
+// is, that they point past the head and within the data.
+func InspectTuple(data []byte, kinds []Kind) (TupleLayout, error) {
+	headLen := 32 * len(kinds)
+	switch {
+	case len(kinds) == 0:
+		return TupleLayout{}, errors.New("no kinds provided")
+	case len(data) == 0:
+		return TupleLayout{}, ErrEmptyInput
+	case len(data) < headLen:
+		return TupleLayout{}, errors.New("not long enough to support all elements")
+	}
+
+	layout := TupleLayout{
+		HeadLen:  headLen,
+		Elements: make([]ElementLayout, len(kinds)),
+	}
+	for i, kind := range kinds {
+		elem := ElementLayout{Kind: kind, HeadStart: 32 * i}
+		switch kind {
+		case Static:
+		case Dynamic:
+			offset, err := DecodeUint64(data[32*i : 32*(i+1)])
+			switch {
+			case err != nil:
+				return TupleLayout{}, fmt.Errorf("decoding offset of element %d, %w", i, err)
+			case offset < uint64(headLen):
+				return TupleLayout{}, fmt.Errorf("offset of element %d points into the head", i)
+			case offset > uint64(len(data)):
+				return TupleLayout{}, fmt.Errorf("offset of element %d out of bounds", i)
+			}
+			elem.Offset = offset
+			elem.TailStart = int(offset)
+		default:
+			return TupleLayout{}, fmt.Errorf("invalid kind %d of element %d", kind, i)
+		}
+		layout.Elements[i] = elem
+	}
+
+	// each region ends where the closest region after it starts
+	for i := range layout.Elements {
+		elem := &layout.Elements[i]
+		if elem.Kind != Dynamic {
+			continue
+		}
+
+		elem.TailEnd = len(data)
+		for _, other := range layout.Elements {
+			if other.Kind == Dynamic && other.TailStart > elem.TailStart {
+				elem.TailEnd = min(elem.TailEnd, other.TailStart)
+			}
+		}
+		if elem.TailEnd-elem.TailStart >= 32 {
+			elem.Length, _ = DecodeUint64(data[elem.TailStart : elem.TailStart+32])
+		}
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,95 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestInspectTuple(t *testing.T) {
+	encoded, err := abi.NewTupleEncoder().
+		Uint64(7).
+		Bytes([]byte("hello")).
+		Uint64(9).
+		Bytes(nZeros(40)).
+		Encode()
+	require.NoError(t, err)
+	kinds := []abi.Kind{abi.Static, abi.Dynamic, abi.Static, abi.Dynamic}
+
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.InspectTuple(encoded, kinds)
+		require.NoError(t, err)
+
+		// then
+		want := abi.TupleLayout{
+			HeadLen: 0x80,
+			Elements: []abi.ElementLayout{
+				{Kind: abi.Static, HeadStart: 0x00},
+				{
+					Kind:      abi.Dynamic,
+					HeadStart: 0x20,
+					Offset:    0x80,
+					TailStart: 0x80,
+					TailEnd:   0xc0,
+					Length:    5,
+				},
+				{Kind: abi.Static, HeadStart: 0x40},
+				{
+					Kind:      abi.Dynamic,
+					HeadStart: 0x60,
+					Offset:    0xc0,
+					TailStart: 0xc0,
+					TailEnd:   0x120,
+					Length:    40,
+				},
+			},
+		}
+		assert.Equal(t, want, got)
+		assert.Equal(t, ""+
+			"head of 128 bytes\n"+
+			"element 0 is static at 0x0\n"+
+			"element 1 is dynamic at offset 0x80, length 5, tail [0x80, 0xc0)\n"+
+			"element 2 is static at 0x40\n"+
+			"element 3 is dynamic at offset 0xc0, length 40, tail [0xc0, 0x120)",
+			got.String(),
+		)
+	})
+
+	t.Run("offset into head", func(t *testing.T) {
+		// given
+		input := append([]byte{}, encoded...)
+		copy(input[32:64], abi.EncodeUint64(0x40))
+		// when
+		_, err := abi.InspectTuple(input, kinds)
+		// then
+		assert.ErrorContains(t, err, "offset of element 1 points into the head")
+	})
+
+	t.Run("offset out of bounds", func(t *testing.T) {
+		// given
+		input := append([]byte{}, encoded...)
+		copy(input[96:128], abi.EncodeUint64(0x1000))
+		// when
+		_, err := abi.InspectTuple(input, kinds)
+		// then
+		assert.ErrorContains(t, err, "offset of element 3 out of bounds")
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		// when
+		_, err := abi.InspectTuple(encoded[:96], kinds)
+		// then
+		assert.ErrorContains(t, err, "not long enough to support all elements")
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		// when
+		_, err := abi.InspectTuple(encoded, []abi.Kind{abi.Static, abi.Kind(5)})
+		// then
+		assert.ErrorContains(t, err, "invalid kind 5 of element 1")
+	})
+}
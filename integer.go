@@ -0,0 +1,245 @@
+package abi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// integer is the set of go integer types, that is, the same set as
+// constraints.Integer without the dependency on golang.org/x/exp.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// integerLayout returns the bit width of T and whether T is signed.
+func integerLayout[T integer]() (int, bool) {
+	var zero T
+	signed := ^zero < 0
+
+	bits := 0
+	for v := T(1); v != 0; v <<= 1 {
+		bits++
+	}
+	return bits, signed
+}
+
+// EncodeInteger encodes a go integer to 32-byte ABI format as an int<N>
+// or uint<N>, where N is the bit width of T.  Signed values are sign
+// extended and unsigned values are zero extended.  It is the inverse
+// operation of DecodeInteger.
+func EncodeInteger[T integer](v T) ([]byte, error) {
+	out := make([]byte, 32)
+	if v < 0 {
+		for i := range out {
+			out[i] = 0xff
+		}
+	}
+
+	// a 64-bit two's complement value extends to 256 bits by its sign
+	binary.BigEndian.PutUint64(out[24:], uint64(v))
+	return out, nil
+}
+
+// DecodeInteger decodes ABI bytes back to a go integer.  It checks that
+// the value fits in T, that is, in an int<N> or uint<N> where N is the bit
+// width of T.  It is the inverse operation of EncodeInteger.  For the
+// unsigned types it is equivalent to the concrete decoders, such as
+// DecodeUint8 or DecodeUint64, which remain the simpler choice when the
+// type is known.
+func DecodeInteger[T integer](v []byte) (T, error) {
+	switch {
+	case len(v) == 0:
+		return 0, ErrEmptyInput
+	case len(v) != 32:
+		return 0, errors.New("integer encoding must contain 32 bytes")
+	}
+
+	bits, signed := integerLayout[T]()
+	if signed {
+		n, err := decodeInt(v, bits)
+		if err != nil {
+			return 0, err
+		}
+		return T(n.Int64()), nil
+	}
+
+	// unsigned values fit in the last word, so they are decoded without
+	// allocating a big.Int
+	n := binary.BigEndian.Uint64(v[24:])
+	if isNonZero(v[:24]) || (bits < 64 && n>>bits != 0) {
+		return 0, fmt.Errorf("value out of range for uint%d", bits)
+	}
+	return T(n), nil
+}
+
+// EncodeInt256 encodes v to 32-byte ABI format as an int256, that is, in
+// two's complement.  It rejects values outside [-2^255, 2^255-1].  It is
+// the inverse operation of DecodeInt256.
+func EncodeInt256(v *big.Int) ([]byte, error) {
+	return encodeInt(v, 256)
+}
+
+// DecodeInt256 decodes ABI bytes back to an int256, interpreting a set top
+// bit as a negative value.  It is the inverse operation of EncodeInt256.
+func DecodeInt256(v []byte) (*big.Int, error) {
+	switch {
+	case len(v) == 0:
+		return nil, ErrEmptyInput
+	case len(v) != 32:
+		return nil, errors.New("int256 encoding must contain 32 bytes")
+	}
+	return decodeInt(v, 256)
+}
+
+// EncodeTupleFuncInt256 encodes an int256 as the k-th element of a tuple.
+func EncodeTupleFuncInt256(v *big.Int) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data, err := EncodeInt256(v)
+		if err != nil {
+			return EncoderResult{}, fmt.Errorf("encoding: %w", err)
+		}
+
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncInt256 decodes an int256 as the k-th element of a tuple
+// into v, which must not be nil.
+func DecodeTupleFuncInt256(v *big.Int) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := DecodeInt256(cur[:32])
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		v.Set(vv)
+		return nil
+	}
+}
+
+// EncodeTupleFuncUint256 encodes a uint256 as the k-th element of a tuple.
+// It rejects values outside [0, 2^256-1].
+func EncodeTupleFuncUint256(v *big.Int) EncoderFunc {
+	return func() (EncoderResult, error) {
+		data, err := encodeUint(v, 256)
+		if err != nil {
+			return EncoderResult{}, fmt.Errorf("encoding: %w", err)
+		}
+
+		return EncoderResult{indirect: false, data: data}, nil
+	}
+}
+
+// DecodeTupleFuncUint256 decodes a uint256 as the k-th element of a tuple
+// into v, which must not be nil.
+func DecodeTupleFuncUint256(v *big.Int) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := decodeUint(cur[:32], 256)
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		v.Set(vv)
+		return nil
+	}
+}
+
+// EncodeUint8 encodes a uint8 to 32-byte ABI format.  It is the inverse
+// operation of DecodeUint8.
+func EncodeUint8(v uint8) []byte {
+	return EncodeUint64(uint64(v))
+}
+
+// EncodeUint16 encodes a uint16 to 32-byte ABI format.  It is the inverse
+// operation of DecodeUint16.
+func EncodeUint16(v uint16) []byte {
+	return EncodeUint64(uint64(v))
+}
+
+// EncodeUint32 encodes a uint32 to 32-byte ABI format.  It is the inverse
+// operation of DecodeUint32.
+func EncodeUint32(v uint32) []byte {
+	return EncodeUint64(uint64(v))
+}
+
+// DecodeUint8 decodes ABI bytes back to a uint8.  A value that does not
+// fit in a uint8 is an error rather than being truncated.  It is the
+// inverse operation of EncodeUint8.
+func DecodeUint8(v []byte) (uint8, error) {
+	return decodeNarrowUint[uint8](v, 8)
+}
+
+// DecodeUint16 decodes ABI bytes back to a uint16.  A value that does not
+// fit in a uint16 is an error rather than being truncated.  It is the
+// inverse operation of EncodeUint16.
+func DecodeUint16(v []byte) (uint16, error) {
+	return decodeNarrowUint[uint16](v, 16)
+}
+
+// DecodeUint32 decodes ABI bytes back to a uint32.  A value that does not
+// fit in a uint32 is an error rather than being truncated.  It is the
+// inverse operation of EncodeUint32.
+func DecodeUint32(v []byte) (uint32, error) {
+	return decodeNarrowUint[uint32](v, 32)
+}
+
+// decodeNarrowUint decodes a uint<bits> with DecodeUint64, which checks
+// the padding, and then checks that the value fits in bits.
+func decodeNarrowUint[T uint8 | uint16 | uint32](v []byte, bits int) (T, error) {
+	n, err := DecodeUint64(v)
+	if err != nil {
+		return 0, err
+	}
+	if n>>bits != 0 {
+		return 0, fmt.Errorf("value %d out of range for uint%d", n, bits)
+	}
+	return T(n), nil
+}
+
+// EncodeTupleFuncUint8 encodes a uint8 as the k-th element of a tuple.
+func EncodeTupleFuncUint8(v uint8) EncoderFunc {
+	return EncodeTupleFuncUint64(uint64(v))
+}
+
+// EncodeTupleFuncUint16 encodes a uint16 as the k-th element of a tuple.
+func EncodeTupleFuncUint16(v uint16) EncoderFunc {
+	return EncodeTupleFuncUint64(uint64(v))
+}
+
+// EncodeTupleFuncUint32 encodes a uint32 as the k-th element of a tuple.
+func EncodeTupleFuncUint32(v uint32) EncoderFunc {
+	return EncodeTupleFuncUint64(uint64(v))
+}
+
+// DecodeTupleFuncUint8 decodes a uint8 as the k-th element of a tuple.
+func DecodeTupleFuncUint8(v *uint8) DecoderFunc {
+	return decodeTupleFuncNarrowUint(v, DecodeUint8)
+}
+
+// DecodeTupleFuncUint16 decodes a uint16 as the k-th element of a tuple.
+func DecodeTupleFuncUint16(v *uint16) DecoderFunc {
+	return decodeTupleFuncNarrowUint(v, DecodeUint16)
+}
+
+// DecodeTupleFuncUint32 decodes a uint32 as the k-th element of a tuple.
+func DecodeTupleFuncUint32(v *uint32) DecoderFunc {
+	return decodeTupleFuncNarrowUint(v, DecodeUint32)
+}
+
+func decodeTupleFuncNarrowUint[T uint8 | uint16 | uint32](
+	v *T,
+	decode func([]byte) (T, error),
+) DecoderFunc {
+	return func(cur, full []byte) error {
+		vv, err := decode(cur)
+		if err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+
+		*v = vv
+		return nil
+	}
+}
@@ -0,0 +1,356 @@
+package abi_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func testIntegerRoundTrip[T int8 | uint32 | int64 | uint64](
+	t *testing.T,
+	typeName string,
+	samples ...T,
+) {
+	t.Run(typeName, func(t *testing.T) {
+		for _, sample := range samples {
+			// when
+			encoded, err := abi.EncodeInteger(sample)
+			require.NoError(t, err)
+
+			got, err := abi.DecodeInteger[T](encoded)
+			require.NoError(t, err)
+
+			// then
+			want, err := abi.EncodeValue(abi.MustParseType(typeName), sample)
+			require.NoError(t, err)
+			assert.Equal(t, want, encoded)
+			assert.Equal(t, sample, got)
+		}
+	})
+}
+
+func TestEncodeDecodeIntegerRoundTrip(t *testing.T) {
+	testIntegerRoundTrip[int8](t, "int8", 0, 1, -1, math.MinInt8, math.MaxInt8)
+	testIntegerRoundTrip[uint32](t, "uint32", 0, 1, math.MaxUint32)
+	testIntegerRoundTrip[int64](t, "int64", 0, -1, math.MinInt64, math.MaxInt64)
+	testIntegerRoundTrip[uint64](t, "uint64", 0, 1, math.MaxUint64)
+}
+
+func TestDecodeInteger(t *testing.T) {
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeInteger[int64](nZeros(31))
+		// then
+		assert.ErrorContains(t, err, "integer encoding must contain 32 bytes")
+	})
+
+	t.Run("too large for int8", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(128)
+		// when
+		_, err := abi.DecodeInteger[int8](input)
+		// then
+		assert.ErrorContains(t, err, "value out of range for int8")
+	})
+
+	t.Run("too small for int8", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeInteger(int64(-129))
+		require.NoError(t, err)
+		// when
+		_, err = abi.DecodeInteger[int8](input)
+		// then
+		assert.ErrorContains(t, err, "value out of range for int8")
+	})
+
+	t.Run("negative for uint32", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeInteger(int8(-1))
+		require.NoError(t, err)
+		// when
+		_, err = abi.DecodeInteger[uint32](input)
+		// then
+		assert.ErrorContains(t, err, "value out of range for uint32")
+	})
+
+	t.Run("too large for uint64", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(1)
+		input[23] = 1
+		// when
+		_, err := abi.DecodeInteger[uint64](input)
+		// then
+		assert.ErrorContains(t, err, "value out of range for uint64")
+	})
+}
+
+func TestDecodeInteger_MatchesConcreteDecoders(t *testing.T) {
+	for _, n := range []uint64{0, 1, math.MaxUint8, math.MaxUint8 + 1,
+		math.MaxUint16, math.MaxUint16 + 1, math.MaxUint32, math.MaxUint32 + 1,
+		math.MaxUint64} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			// given
+			input := abi.EncodeUint64(n)
+
+			// when
+			got8, err8 := abi.DecodeInteger[uint8](input)
+			want8, wantErr8 := abi.DecodeUint8(input)
+			got16, err16 := abi.DecodeInteger[uint16](input)
+			want16, wantErr16 := abi.DecodeUint16(input)
+			got32, err32 := abi.DecodeInteger[uint32](input)
+			want32, wantErr32 := abi.DecodeUint32(input)
+			got64, err64 := abi.DecodeInteger[uint64](input)
+			want64, wantErr64 := abi.DecodeUint64(input)
+
+			// then
+			assert.Equal(t, want8, got8)
+			assert.Equal(t, wantErr8 == nil, err8 == nil)
+			assert.Equal(t, want16, got16)
+			assert.Equal(t, wantErr16 == nil, err16 == nil)
+			assert.Equal(t, want32, got32)
+			assert.Equal(t, wantErr32 == nil, err32 == nil)
+			assert.Equal(t, want64, got64)
+			assert.Equal(t, wantErr64 == nil, err64 == nil)
+		})
+	}
+}
+
+func TestEncodeDecodeInt256(t *testing.T) {
+	one := big.NewInt(1)
+	minInt256 := new(big.Int).Neg(new(big.Int).Lsh(one, 255))
+	maxInt256 := new(big.Int).Sub(new(big.Int).Lsh(one, 255), one)
+
+	for _, tc := range []struct {
+		name    string
+		value   *big.Int
+		encoded []byte
+	}{
+		{"zero", new(big.Int), nZeros(32)},
+		{"one", big.NewInt(1), abi.EncodeUint64(1)},
+		{"minus one", big.NewInt(-1), bytes.Repeat([]byte{0xff}, 32)},
+		{
+			"most negative",
+			minInt256,
+			append([]byte{0x80}, nZeros(31)...),
+		},
+		{
+			"most positive",
+			maxInt256,
+			append([]byte{0x7f}, bytes.Repeat([]byte{0xff}, 31)...),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			encoded, err := abi.EncodeInt256(tc.value)
+			require.NoError(t, err)
+
+			got, err := abi.DecodeInt256(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, tc.encoded, encoded)
+			assert.Equal(t, 0, tc.value.Cmp(got))
+		})
+	}
+
+	t.Run("2^255 out of range", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeInt256(new(big.Int).Lsh(one, 255))
+		// then
+		assert.ErrorContains(t, err, "value out of range for int256")
+	})
+
+	t.Run("below -2^255 out of range", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeInt256(new(big.Int).Sub(minInt256, one))
+		// then
+		assert.ErrorContains(t, err, "value out of range for int256")
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeInt256(nZeros(31))
+		// then
+		assert.ErrorContains(t, err, "int256 encoding must contain 32 bytes")
+	})
+}
+
+func TestTupleEncoderDecoder_Int256(t *testing.T) {
+	// given
+	a, b := big.NewInt(-5), new(big.Int).Lsh(big.NewInt(1), 200)
+	encoded, err := abi.NewTupleEncoder().Int256(a).Uint64(7).Int256(b).Encode()
+	require.NoError(t, err)
+
+	// when
+	gotA, gotB := new(big.Int), new(big.Int)
+	var gotU uint64
+	err = abi.NewTupleDecoder().Int256(gotA).Uint64(&gotU).Int256(gotB).Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	want, err := abi.Encode(
+		[]abi.Type{abi.IntType(256), abi.UintType(64), abi.IntType(256)},
+		[]any{a, 7, b},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, encoded)
+	assert.Equal(t, 0, a.Cmp(gotA))
+	assert.Equal(t, uint64(7), gotU)
+	assert.Equal(t, 0, b.Cmp(gotB))
+}
+
+func TestEncodeDecodeNarrowUint(t *testing.T) {
+	t.Run("round trip at the maximum", func(t *testing.T) {
+		// when
+		got8, err8 := abi.DecodeUint8(abi.EncodeUint8(math.MaxUint8))
+		got16, err16 := abi.DecodeUint16(abi.EncodeUint16(math.MaxUint16))
+		got32, err32 := abi.DecodeUint32(abi.EncodeUint32(math.MaxUint32))
+		// then
+		require.NoError(t, err8)
+		require.NoError(t, err16)
+		require.NoError(t, err32)
+		assert.Equal(t, uint8(math.MaxUint8), got8)
+		assert.Equal(t, uint16(math.MaxUint16), got16)
+		assert.Equal(t, uint32(math.MaxUint32), got32)
+	})
+
+	t.Run("same layout as uint64", func(t *testing.T) {
+		assert.Equal(t, abi.EncodeUint64(200), abi.EncodeUint8(200))
+		assert.Equal(t, abi.EncodeUint64(60000), abi.EncodeUint16(60000))
+		assert.Equal(t, abi.EncodeUint64(1<<31), abi.EncodeUint32(1<<31))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		badPadding := abi.EncodeUint64(1)
+		badPadding[0] = 0x01
+
+		for _, tc := range []struct {
+			name    string
+			decode  func([]byte) error
+			input   []byte
+			wantErr string
+		}{
+			{
+				name:    "uint8 out of range",
+				decode:  func(v []byte) error { _, err := abi.DecodeUint8(v); return err },
+				input:   abi.EncodeUint64(256),
+				wantErr: "value 256 out of range for uint8",
+			},
+			{
+				name:    "uint16 out of range",
+				decode:  func(v []byte) error { _, err := abi.DecodeUint16(v); return err },
+				input:   abi.EncodeUint64(1 << 16),
+				wantErr: "value 65536 out of range for uint16",
+			},
+			{
+				name:    "uint32 out of range",
+				decode:  func(v []byte) error { _, err := abi.DecodeUint32(v); return err },
+				input:   abi.EncodeUint64(1 << 32),
+				wantErr: "value 4294967296 out of range for uint32",
+			},
+			{
+				name:    "non-zero padding",
+				decode:  func(v []byte) error { _, err := abi.DecodeUint8(v); return err },
+				input:   badPadding,
+				wantErr: "padding contains non-zero values at index 0",
+			},
+			{
+				name:    "wrong length",
+				decode:  func(v []byte) error { _, err := abi.DecodeUint32(v); return err },
+				input:   nZeros(31),
+				wantErr: "must contain 32 bytes",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				err := tc.decode(tc.input)
+				// then
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+func TestTupleEncoderDecoder_NarrowUint(t *testing.T) {
+	// given
+	encoded, err := abi.NewTupleEncoder().Uint8(8).Uint16(16).Uint32(32).Encode()
+	require.NoError(t, err)
+
+	// when
+	var got8 uint8
+	var got16 uint16
+	var got32 uint32
+	err = abi.NewTupleDecoder().Uint8(&got8).Uint16(&got16).Uint32(&got32).Decode(encoded)
+	require.NoError(t, err)
+
+	// then
+	want, err := abi.Encode(
+		[]abi.Type{abi.UintType(8), abi.UintType(16), abi.UintType(32)},
+		[]any{8, 16, 32},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, encoded)
+	assert.Equal(t, uint8(8), got8)
+	assert.Equal(t, uint16(16), got16)
+	assert.Equal(t, uint32(32), got32)
+
+	t.Run("out of range element", func(t *testing.T) {
+		// given
+		input := abi.EncodeUint64(300)
+		// when
+		err := abi.NewTupleDecoder().Uint8(&got8).Decode(input)
+		// then
+		assert.ErrorContains(t, err, "out of range for uint8")
+	})
+}
+
+func TestTupleEncoder_Uint256(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		v := new(big.Int).Lsh(big.NewInt(1), 200)
+		// when
+		got, err := abi.NewTupleEncoder().Uint256(v).Uint64(7).Encode()
+		// then
+		require.NoError(t, err)
+		want, err := abi.Encode(
+			[]abi.Type{abi.UintType(256), abi.UintType(64)},
+			[]any{v, 7},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		// when
+		_, err := abi.NewTupleEncoder().Uint256(big.NewInt(-1)).Encode()
+		// then
+		assert.ErrorContains(t, err, "value out of range for uint256")
+	})
+}
+
+func TestTupleDecoder_Uint256(t *testing.T) {
+	// given
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	encoded, err := abi.Encode(
+		[]abi.Type{abi.UintType(256), abi.UintType(64)},
+		[]any{maxUint256, 7},
+	)
+	require.NoError(t, err)
+
+	// when
+	got := new(big.Int)
+	var gotU uint64
+	err = abi.NewTupleDecoder().Uint256(got).Uint64(&gotU).Decode(encoded)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 0, maxUint256.Cmp(got))
+	assert.Equal(t, uint64(7), gotU)
+}
@@ -0,0 +1,54 @@
+package abi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EncodeBytesLE encodes a byte slice like EncodeBytes, except that the
+// length in the head is stored as a little-endian uint64 in the first 8
+// bytes of the head word.  This is NOT ABI compliant, it is an interop shim
+// for systems that store lengths in that way.  It is the inverse operation
+// of DecodeBytesLE.
+func EncodeBytesLE(v []byte) ([]byte, error) {
+	vLen := len(v)
+	head := make([]byte, 32)
+	binary.LittleEndian.PutUint64(head, uint64(vLen))
+	alignedLen, err := nextMultipleOf32(vLen)
+	if err != nil {
+		return nil, fmt.Errorf("padding, %w", err)
+	}
+	tail, err := padRight(v, alignedLen)
+	if err != nil {
+		return nil, fmt.Errorf("padding, %w", err)
+	}
+
+	return append(head, tail...), nil
+}
+
+// DecodeBytesLE decodes a byte slice like DecodeBytes, except that the
+// length in the head is read as a little-endian uint64 from the first 8
+// bytes of the head word.  This is NOT ABI compliant, it is an interop shim
+// for systems that store lengths in that way.  Apart from the byte order of
+// the length, all validation is identical to DecodeBytes.  It is the
+// inverse operation of EncodeBytesLE.
+func DecodeBytesLE(abiEncoded []byte) ([]byte, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return decodeBytesWithLength(abiEncoded, &DecodeOptions{}, decodeUint64LE)
+}
+
+func decodeUint64LE(v []byte) (uint64, error) {
+	if len(v) != 32 {
+		return 0, errors.New("uint64 encoding must contain 32 bytes")
+	}
+
+	data, padding := v[:8], v[8:]
+	if err := checkPadding(padding); err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(data), nil
+}
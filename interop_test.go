@@ -0,0 +1,89 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeBytesLE(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := append([]byte{5}, nZeros(31)...)
+		want = append(want, []byte("hello")...)
+		want = append(want, nZeros(27)...)
+		// when
+		got, err := abi.EncodeBytesLE([]byte("hello"))
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestDecodeBytesLE(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		input := append([]byte{5}, nZeros(31)...)
+		input = append(input, []byte("hello")...)
+		input = append(input, nZeros(27)...)
+		// when
+		got, err := abi.DecodeBytesLE(input)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []byte("hello"), got)
+	})
+
+	t.Run("big-endian length is rejected", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		// when
+		_, err = abi.DecodeBytesLE(input)
+		// then
+		assert.ErrorContains(t, err, "decoding data length")
+	})
+
+	t.Run("not 32-byte aligned", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytesLE([]byte("hello"))
+		require.NoError(t, err)
+		// when
+		_, err = abi.DecodeBytesLE(input[:40])
+		// then
+		assert.ErrorContains(t, err, "not 32-byte aligned")
+	})
+
+	t.Run("padding has non-zero values", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeBytesLE([]byte("hello"))
+		require.NoError(t, err)
+		input[len(input)-1] = 7
+		// when
+		_, err = abi.DecodeBytesLE(input)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+}
+
+func TestEncodeDecodeBytesLERoundTrip(t *testing.T) {
+	for name, input := range map[string][]byte{
+		"empty":       {},
+		"a-few-bytes": []byte("hello"),
+		"multi-lines": []byte("40-bytes-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			encoded, err := abi.EncodeBytesLE(input)
+			require.NoError(t, err)
+
+			got, err := abi.DecodeBytesLE(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+}
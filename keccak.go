@@ -0,0 +1,15 @@
+package abi
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// Keccak256 returns the Keccak-256 hash of data, as used by the EVM.  Note
+// that Keccak-256 differs from the standardized SHA3-256.
+func Keccak256(data []byte) [32]byte {
+	var out [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(out[:0])
+	return out
+}
@@ -0,0 +1,36 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blocky/abi"
+)
+
+func TestKeccak256(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "",
+			want:  "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			input: "hello",
+			want:  "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8",
+		},
+		{
+			input: "Transfer(address,address,uint256)",
+			want:  "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			// when
+			got := abi.Keccak256([]byte(tc.input))
+			// then
+			assert.Equal(t, hexDecode(tc.want), got[:])
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package abi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// EncodeMapAsArrays encodes m as the tuple (K[] keys, V[] values) of
+// parallel arrays, where encK and encV give the encoder of each key and
+// value, for example, EncodeTupleFuncAddress for address keys.  Static
+// keys and values, such as address or uint256, are stored inline in their
+// arrays, while dynamic ones, such as bytes, are referenced by offsets.
+// Entries are sorted by their encoded keys, so that the same map always
+// produces the same bytes, for example, when the encoding is hashed.  It
+// is the inverse operation of DecodeMapFromArrays.
+func EncodeMapAsArrays[K comparable, V any](
+	m map[K]V,
+	encK func(K) EncoderFunc,
+	encV func(V) EncoderFunc,
+) ([]byte, error) {
+	type entry struct {
+		key   EncoderResult
+		value EncoderResult
+	}
+
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		key, err := encK(k)()
+		if err != nil {
+			return nil, fmt.Errorf("encoding key: %w", err)
+		}
+		value, err := encV(v)()
+		if err != nil {
+			return nil, fmt.Errorf("encoding value: %w", err)
+		}
+		entries = append(entries, entry{key: key, value: value})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return bytes.Compare(a.key.data, b.key.data)
+	})
+
+	keys := make([]EncoderResult, len(entries))
+	values := make([]EncoderResult, len(entries))
+	for i := range entries {
+		if i > 0 && bytes.Equal(entries[i-1].key.data, entries[i].key.data) {
+			return nil, fmt.Errorf("distinct keys encode to 0x%x", entries[i].key.data)
+		}
+		keys[i] = entries[i].key
+		values[i] = entries[i].value
+	}
+
+	return EncodeTuple(encodeTupleFuncArray(keys), encodeTupleFuncArray(values))
+}
+
+// encodeTupleFuncArray encodes the already encoded elements as a dynamic
+// array, that is, the element count followed by the elements laid out
+// like the fields of a tuple.  The elements must be all static or all
+// dynamic, as they share a type.
+func encodeTupleFuncArray(elems []EncoderResult) EncoderFunc {
+	return func() (EncoderResult, error) {
+		for i := 1; i < len(elems); i++ {
+			if elems[i].indirect != elems[0].indirect {
+				return EncoderResult{}, fmt.Errorf("element %d, layout differs from element 0", i)
+			}
+		}
+
+		body, err := assembleTuple(elems)
+		if err != nil {
+			return EncoderResult{}, err
+		}
+		data := append(EncodeUint64(uint64(len(elems))), body...)
+		return EncoderResult{indirect: true, data: data}, nil
+	}
+}
+
+// DecodeMapFromArrays decodes a map encoded as the tuple (K[] keys, V[]
+// values) of parallel arrays, where decK and decV give the decoder of each
+// key and value, for example, DecodeTupleFuncAddress for address keys.
+// Each key and value must take up a single head slot, as for DecodeSlice.
+// It is the inverse operation of EncodeMapAsArrays.
+func DecodeMapFromArrays[K comparable, V any](
+	data []byte,
+	decK func(*K) DecoderFunc,
+	decV func(*V) DecoderFunc,
+) (map[K]V, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	var keys []K
+	var values []V
+	err := DecodeTuple(
+		data,
+		decodeTupleFuncArray(&keys, decK),
+		decodeTupleFuncArray(&values, decV),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != len(values) {
+		format := "got %d keys but %d values"
+		return nil, fmt.Errorf(format, len(keys), len(values))
+	}
+
+	m := make(map[K]V, len(keys))
+	for i := range keys {
+		if _, ok := m[keys[i]]; ok {
+			return nil, fmt.Errorf("duplicate key at index %d", i)
+		}
+		m[keys[i]] = values[i]
+	}
+
+	return m, nil
+}
+
+// decodeTupleFuncArray decodes a dynamic array as the k-th element of a
+// tuple into v, where dec gives the decoder of each element.  It is the
+// inverse operation of encodeTupleFuncArray.
+func decodeTupleFuncArray[T any](v *[]T, dec func(*T) DecoderFunc) DecoderFunc {
+	return func(cur, full []byte) error {
+		offset, err := DecodeUint64(cur)
+		switch {
+		case err != nil:
+			return fmt.Errorf("decoding offset: %w", err)
+		case offset > uint64(len(full)) || uint64(len(full))-offset < 32:
+			return errors.New("offset out of bounds")
+		}
+
+		eltCount, err := DecodeUint64(full[offset : offset+32])
+		if err != nil {
+			return fmt.Errorf("decoding element count: %w", err)
+		}
+
+		// the elements are laid out like the fields of a tuple that
+		// starts after the element count
+		elems := full[offset+32:]
+		if eltCount > uint64(len(elems)/32) {
+			return fmt.Errorf("tail too short for %d elements", eltCount)
+		}
+
+		out := make([]T, eltCount)
+		if eltCount > 0 {
+			decoders := make([]DecoderFunc, eltCount)
+			for i := range decoders {
+				decoders[i] = dec(&out[i])
+			}
+			if err := decodeTuple(context.Background(), elems, decoders); err != nil {
+				return err
+			}
+		}
+		*v = out
+		return nil
+	}
+}
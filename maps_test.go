@@ -0,0 +1,155 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeMapAsArrays(t *testing.T) {
+	t.Run("sorted by key", func(t *testing.T) {
+		// given
+		m := map[string]uint64{"b": 2, "c": 3, "a": 1}
+		want, err := abi.Encode(
+			[]abi.Type{abi.SliceType(abi.StringType()), abi.SliceType(abi.UintType(64))},
+			[]any{[]any{"a", "b", "c"}, []any{1, 2, 3}},
+		)
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.EncodeMapAsArrays(m, abi.EncodeTupleFuncString, abi.EncodeTupleFuncUint64)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("static keys inline", func(t *testing.T) {
+		// given
+		first, second := [20]byte{1}, [20]byte{2}
+		m := map[[20]byte]*big.Int{second: big.NewInt(2), first: big.NewInt(1)}
+		want, err := abi.Encode(
+			[]abi.Type{abi.SliceType(abi.AddressType()), abi.SliceType(abi.UintType(256))},
+			[]any{[]any{first, second}, []any{1, 2}},
+		)
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.EncodeMapAsArrays(m, abi.EncodeTupleFuncAddress, abi.EncodeTupleFuncUint256)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("distinct keys encode identically", func(t *testing.T) {
+		// given
+		m := map[string]uint64{"a": 1, "b": 2}
+		encK := func(string) abi.EncoderFunc { return abi.EncodeTupleFuncString("same") }
+		// when
+		_, err := abi.EncodeMapAsArrays(m, encK, abi.EncodeTupleFuncUint64)
+		// then
+		assert.ErrorContains(t, err, "distinct keys encode to 0x")
+	})
+
+	t.Run("keys with different layouts", func(t *testing.T) {
+		// given
+		m := map[uint64]uint64{1: 1, 2: 2}
+		encK := func(k uint64) abi.EncoderFunc {
+			if k == 1 {
+				return abi.EncodeTupleFuncUint64(k)
+			}
+			return abi.EncodeTupleFuncBytes(abi.EncodeUint64(k))
+		}
+		// when
+		_, err := abi.EncodeMapAsArrays(m, encK, abi.EncodeTupleFuncUint64)
+		// then
+		assert.ErrorContains(t, err, "layout differs from element 0")
+	})
+}
+
+func TestDecodeMapFromArrays(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// given
+		want := map[string]uint64{"one": 1, "two": 2, "three": 3, "": 0}
+		encoded, err := abi.EncodeMapAsArrays(want, abi.EncodeTupleFuncString, abi.EncodeTupleFuncUint64)
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeMapFromArrays(encoded, abi.DecodeTupleFuncString, abi.DecodeTupleFuncUint64)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("static keys", func(t *testing.T) {
+		// given
+		want := map[[20]byte]uint64{{1}: 1, {2}: 2}
+		encoded, err := abi.EncodeMapAsArrays(want, abi.EncodeTupleFuncAddress, abi.EncodeTupleFuncUint64)
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeMapFromArrays(encoded, abi.DecodeTupleFuncAddress, abi.DecodeTupleFuncUint64)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("empty map", func(t *testing.T) {
+		// given
+		encoded, err := abi.EncodeMapAsArrays(
+			map[string]uint64{},
+			abi.EncodeTupleFuncString,
+			abi.EncodeTupleFuncUint64,
+		)
+		require.NoError(t, err)
+		// when
+		got, err := abi.DecodeMapFromArrays(encoded, abi.DecodeTupleFuncString, abi.DecodeTupleFuncUint64)
+		require.NoError(t, err)
+		// then
+		assert.Empty(t, got)
+		assert.NotNil(t, got)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		keys   []any
+		values []any
+		want   string
+	}{
+		{
+			name:   "arrays differ in length",
+			keys:   []any{"a"},
+			values: []any{},
+			want:   "got 1 keys but 0 values",
+		},
+		{
+			name:   "invalid value",
+			keys:   []any{"a"},
+			values: []any{new(big.Int).Lsh(big.NewInt(1), 64)},
+			want:   "decoding element 1: decoding element 0",
+		},
+		{
+			name:   "duplicate key",
+			keys:   []any{"a", "a"},
+			values: []any{1, 2},
+			want:   "duplicate key at index 1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			// the values are wider than uint64, so that they can overflow it
+			encoded, err := abi.Encode(
+				[]abi.Type{abi.SliceType(abi.StringType()), abi.SliceType(abi.UintType(256))},
+				[]any{tc.keys, tc.values},
+			)
+			require.NoError(t, err)
+			// when
+			_, err = abi.DecodeMapFromArrays(encoded, abi.DecodeTupleFuncString, abi.DecodeTupleFuncUint64)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// Call is a single call of a Multicall aggregate, that is, the solidity
+// struct (address target, bytes callData).
+type Call struct {
+	Target [20]byte
+	Data   []byte
+}
+
+// EncodeMulticall encodes calls as the (address,bytes)[] argument of a
+// Multicall aggregate.  It is the inverse operation of DecodeMulticall.
+func EncodeMulticall(calls []Call) ([]byte, error) {
+	// Each call is a dynamic tuple (because of its bytes field) and so
+	// calls are laid out just like the elements of a slice of bytes,
+	// with an offset per element followed by the encoded elements.
+	encodedElems := make([][]byte, len(calls))
+	for i := range calls {
+		enc, err := EncodeTuple(
+			EncodeTupleFuncAddress(calls[i].Target),
+			EncodeTupleFuncBytes(calls[i].Data),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("encoding call %d, %w", i, err)
+		}
+		encodedElems[i] = enc
+	}
+
+	return encodeSliceOfDynamic(encodedElems), nil
+}
+
+// DecodeMulticall decodes the (address,bytes)[] argument of a Multicall
+// aggregate.  It is the inverse operation of EncodeMulticall.
+func DecodeMulticall(abiEncoded []byte) ([]Call, error) {
+	if len(abiEncoded) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	elems, err := splitSliceOfDynamic(abiEncoded, &DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]Call, len(elems))
+	for i := range elems {
+		// offsets within a call are relative to the start of the call,
+		// so each call is decoded from its own region
+		err := DecodeTuple(elems[i],
+			DecodeTupleFuncAddress(&calls[i].Target),
+			DecodeTupleFuncBytes(&calls[i].Data),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("decoding call %d, %w", i, err)
+		}
+	}
+
+	return calls, nil
+}
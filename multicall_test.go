@@ -0,0 +1,109 @@
+package abi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func repeatAddress(b byte) [20]byte {
+	var addr [20]byte
+	copy(addr[:], bytes.Repeat([]byte{b}, 20))
+	return addr
+}
+
+// twoCalls is encoded by go-ethereum as the (address,bytes)[] argument
+// of a Multicall aggregate.
+var twoCalls = struct {
+	native  []abi.Call
+	encoded []byte
+}{
+	native: []abi.Call{
+		{Target: repeatAddress(0x11), Data: []byte{1, 2, 3, 4}},
+		{Target: repeatAddress(0x22), Data: nZeros(40)},
+	},
+	encoded: hexDecode("" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000c0" +
+		"0000000000000000000000001111111111111111111111111111111111111111" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"0102030400000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000002222222222222222222222222222222222222222" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000028" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	),
+}
+
+func TestEncodeMulticall(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeMulticall(twoCalls.native)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, twoCalls.encoded, got)
+	})
+}
+
+func TestDecodeMulticall(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeMulticall(twoCalls.encoded)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, twoCalls.native, got)
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		input := bytes.Clone(twoCalls.encoded)
+		input[2] = 1
+		// when
+		_, err := abi.DecodeMulticall(input)
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("bad target", func(t *testing.T) {
+		// given
+		input := bytes.Clone(twoCalls.encoded)
+		// bytes [0, 64) encode the head
+		// bytes [64, 128) encode the offsets
+		// bytes [128, 160) encode the target of the first call
+		input[128] = 1
+		// when
+		_, err := abi.DecodeMulticall(input)
+		// then
+		assert.ErrorContains(t, err, "decoding call 0")
+	})
+}
+
+func TestEncodeDecodeMulticallRoundTrip(t *testing.T) {
+	for name, input := range map[string][]abi.Call{
+		"no calls": {},
+		"two calls": {
+			{Target: repeatAddress(0xaa), Data: []byte("short")},
+			{Target: repeatAddress(0xbb), Data: bytes.Repeat([]byte("long"), 20)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			encoded, err := abi.EncodeMulticall(input)
+			require.NoError(t, err)
+
+			got, err := abi.DecodeMulticall(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, input, got)
+		})
+	}
+}
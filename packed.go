@@ -0,0 +1,105 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// PackedArg is an argument of EncodePacked, that is, a value together with
+// the type that decides its width in the packed encoding.  Values are
+// given as for Encode.
+type PackedArg struct {
+	Type  Type
+	Value any
+}
+
+// Packed creates a PackedArg of value v with type t.
+func Packed(t Type, v any) PackedArg {
+	return PackedArg{Type: t, Value: v}
+}
+
+// EncodePacked encodes args like abi.encodePacked in solidity.  This is NOT
+// the standard ABI encoding, values are concatenated without padding or
+// offsets, which makes the encoding ambiguous and suitable only for
+// hashing.  Each argument is encoded as:
+//
+//   - uint<N> and int<N> as N/8 big-endian bytes, in two's complement
+//   - bool as a single byte
+//   - address as 20 bytes
+//   - bytes<N> as N bytes
+//   - bytes and string as their raw content, without a length
+//   - slices and arrays of static elements as the elements, each padded
+//     to 32 bytes as in the standard encoding
+//
+// Tuples, and slices and arrays of dynamic elements, are not supported, as
+// solidity does not support them either.
+func EncodePacked(args ...PackedArg) ([]byte, error) {
+	var out []byte
+	for i := range args {
+		if err := args[i].Type.validate(); err != nil {
+			return nil, fmt.Errorf("invalid type for argument %d: %w", i, err)
+		}
+
+		data, err := encodePacked(args[i].Type, args[i].Value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding argument %d: %w", i, err)
+		}
+		out = append(out, data...)
+	}
+	return out, nil
+}
+
+// encodePacked encodes a single argument of EncodePacked.
+func encodePacked(t Type, v any) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind, BoolKind, AddressKind, FixedBytesKind:
+		res, err := encodeType(t, v)
+		if err != nil {
+			return nil, err
+		}
+		return packedWord(t, res.data), nil
+	case BytesKind:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, typeMismatch(t, v)
+		}
+		return b, nil
+	case StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return nil, typeMismatch(t, v)
+		}
+		return []byte(s), nil
+	case SliceKind, ArrayKind:
+		if t.Elem.IsDynamic() || t.Elem.Kind == TupleKind {
+			return nil, fmt.Errorf("packed encoding of %s is not supported", t)
+		}
+
+		elems, ok := v.([]any)
+		switch {
+		case !ok:
+			return nil, typeMismatch(t, v)
+		case t.Kind == ArrayKind && len(elems) != t.Size:
+			return nil, fmt.Errorf("%s value must contain %d elements", t, t.Size)
+		}
+
+		// elements of arrays are padded, so they are encoded as the
+		// fields of a static tuple
+		return encodeSequence(elems, func(int) Type { return *t.Elem })
+	}
+	return nil, fmt.Errorf("packed encoding of %s is not supported", t)
+}
+
+// packedWord returns the packed part of the standard 32-byte encoding of a
+// value of static elementary type t.
+func packedWord(t Type, word []byte) []byte {
+	switch t.Kind {
+	case UintKind, IntKind:
+		return word[32-t.Size/8:]
+	case BoolKind:
+		return word[31:]
+	case AddressKind:
+		return word[12:]
+	default:
+		return word[:t.Size]
+	}
+}
@@ -0,0 +1,99 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodePacked(t *testing.T) {
+	t.Run("solidity documentation example", func(t *testing.T) {
+		// given
+		// abi.encodePacked(int16(-1), bytes1(0x42), uint16(0x03), string("Hello, world!"))
+		want := hexDecode("ffff42000348656c6c6f2c20776f726c6421")
+
+		// when
+		got, err := abi.EncodePacked(
+			abi.Packed(abi.IntType(16), -1),
+			abi.Packed(abi.FixedBytesType(1), []byte{0x42}),
+			abi.Packed(abi.UintType(16), 3),
+			abi.Packed(abi.StringType(), "Hello, world!"),
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("uint8 address and string", func(t *testing.T) {
+		// given
+		addr := hexAddress("dac17f958d2ee523a2206206994597c13d831ec7")
+		want := hexDecode("" +
+			"01" +
+			"dac17f958d2ee523a2206206994597c13d831ec7" +
+			"737472",
+		)
+
+		// when
+		got, err := abi.EncodePacked(
+			abi.Packed(abi.UintType(8), 1),
+			abi.Packed(abi.AddressType(), addr),
+			abi.Packed(abi.StringType(), "str"),
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("bool uint256 and bytes", func(t *testing.T) {
+		// given
+		want := append([]byte{0x01}, abi.EncodeUint64(7)...)
+		want = append(want, 0xde, 0xad)
+
+		// when
+		got, err := abi.EncodePacked(
+			abi.Packed(abi.BoolType(), true),
+			abi.Packed(abi.UintType(256), big.NewInt(7)),
+			abi.Packed(abi.BytesType(), []byte{0xde, 0xad}),
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("array elements are padded", func(t *testing.T) {
+		// given
+		want := append(abi.EncodeUint64(1), abi.EncodeUint64(2)...)
+
+		// when
+		got, err := abi.EncodePacked(
+			abi.Packed(abi.SliceType(abi.UintType(16)), []any{1, 2}),
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		// when
+		_, err := abi.EncodePacked(abi.Packed(abi.UintType(8), 256))
+		// then
+		assert.ErrorContains(t, err, "encoding argument 0: value out of range for uint8")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		// when
+		_, err := abi.EncodePacked(
+			abi.Packed(abi.SliceType(abi.StringType()), []any{"a"}),
+		)
+		// then
+		assert.ErrorContains(t, err, "packed encoding of string[] is not supported")
+	})
+}
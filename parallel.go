@@ -0,0 +1,55 @@
+package abi
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// DecodeSliceOfBytesParallel decodes a slice of byte arrays like
+// DecodeSliceOfBytes, but decodes the elements concurrently using up to
+// workers goroutines.  Offsets are resolved sequentially, as that is
+// cheap, and then the elements are decoded in parallel.  The order of the
+// elements is preserved and, if several elements are invalid, the error
+// for the element with the lowest index is returned.
+func DecodeSliceOfBytesParallel(abiEncoded []byte, workers int) ([][]byte, error) {
+	switch {
+	case workers < 1:
+		return nil, fmt.Errorf("invalid worker count %d", workers)
+	case len(abiEncoded) == 0:
+		return nil, ErrEmptyInput
+	}
+
+	elems, err := splitSliceOfDynamic(abiEncoded, &DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(elems))
+	errs := make([]error, len(elems))
+
+	// each worker claims the next element to decode until none are left
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(elems)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(elems) {
+					return
+				}
+				results[i], errs[i] = DecodeBytes(elems[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("decoding element %d, %w", i, errs[i])
+		}
+	}
+	return results, nil
+}
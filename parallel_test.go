@@ -0,0 +1,73 @@
+package abi_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestDecodeSliceOfBytesParallel(t *testing.T) {
+	for _, tc := range testData.sliceOfBytes {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := abi.DecodeSliceOfBytesParallel(tc.encoded, 4)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, tc.native, got)
+		})
+	}
+
+	for _, workers := range []int{1, 3, 16, 2000} {
+		t.Run(fmt.Sprintf("%d workers preserve order", workers), func(t *testing.T) {
+			// given
+			want := make([][]byte, 1000)
+			for i := range want {
+				want[i] = bytes.Repeat([]byte{byte(i)}, i%70)
+			}
+			input, err := abi.EncodeSliceOfBytes(want)
+			require.NoError(t, err)
+
+			// when
+			got, err := abi.DecodeSliceOfBytesParallel(input, workers)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("invalid worker count", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeSliceOfBytesParallel(testData.sliceOfBytes[0].encoded, 0)
+		// then
+		assert.ErrorContains(t, err, "invalid worker count 0")
+	})
+
+	t.Run("invalid layout", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeSliceOfBytesParallel([]byte("too-short"), 2)
+		// then
+		assert.ErrorContains(t, err, "not long enough to have a head")
+	})
+
+	t.Run("first invalid element is reported", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeSliceOfBytes([][]byte{{1}, {2}, {3}})
+		require.NoError(t, err)
+		// bytes [0, 64) encode the head, [64, 160) encode the offsets and
+		// each element takes 64 bytes, so we corrupt the padding of the
+		// last two elements
+		input[160+64+63] = 1
+		input[160+128+63] = 1
+		// when
+		_, err = abi.DecodeSliceOfBytesParallel(input, 3)
+		// then
+		assert.ErrorContains(t, err, "decoding element 1")
+	})
+}
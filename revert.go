@@ -0,0 +1,110 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrorSelector is the selector of Error(string), which solidity uses to
+	// revert with a reason string.
+	ErrorSelector = [4]byte{0x08, 0xc3, 0x79, 0xa0}
+	// PanicSelector is the selector of Panic(uint256), which solidity uses
+	// to revert on failed assertions and runtime errors.
+	PanicSelector = [4]byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to invalid internal function",
+}
+
+// PanicReason returns a human-readable reason for a solidity Panic code.
+func PanicReason(code *big.Int) string {
+	if code == nil {
+		return "unknown panic code"
+	}
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return fmt.Sprintf("unknown panic code 0x%02x", code)
+}
+
+// DecodeRevertReason decodes the reason string from the return data of a
+// call that reverted with Error(string).
+func DecodeRevertReason(returndata []byte) (string, error) {
+	args, err := revertArgs(returndata, ErrorSelector)
+	if err != nil {
+		return "", err
+	}
+
+	reason, err := DecodeValue(args, StringType(), DecodeOptions{})
+	if err != nil {
+		return "", fmt.Errorf("decoding reason: %w", err)
+	}
+	return reason.(string), nil
+}
+
+// DecodePanicCode decodes the code from the return data of a call that
+// reverted with Panic(uint256).
+func DecodePanicCode(returndata []byte) (*big.Int, error) {
+	args, err := revertArgs(returndata, PanicSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := DecodeValue(args, UintType(256), DecodeOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("decoding code: %w", err)
+	}
+	return code.(*big.Int), nil
+}
+
+// TryDecodeError decodes the return data of a call that reverted with
+// either Error(string) or Panic(uint256).  It returns the reason string of
+// an Error and the PanicReason of a Panic.  Return data of any other
+// shape, such as a custom error, results in an error.
+func TryDecodeError(returndata []byte) (string, error) {
+	switch {
+	case len(returndata) == 0:
+		return "", ErrEmptyInput
+	case len(returndata) < 4:
+		return "", errors.New("return data too short to have a selector")
+	case bytes.Equal(returndata[:4], ErrorSelector[:]):
+		return DecodeRevertReason(returndata)
+	case bytes.Equal(returndata[:4], PanicSelector[:]):
+		code, err := DecodePanicCode(returndata)
+		if err != nil {
+			return "", err
+		}
+		return PanicReason(code), nil
+	}
+	return "", fmt.Errorf("unknown error selector 0x%x", returndata[:4])
+}
+
+// revertArgs checks that returndata starts with selector and returns the
+// encoded arguments that follow it.
+func revertArgs(returndata []byte, selector [4]byte) ([]byte, error) {
+	switch {
+	case len(returndata) == 0:
+		return nil, ErrEmptyInput
+	case len(returndata) < 4:
+		return nil, errors.New("return data too short to have a selector")
+	case !bytes.Equal(returndata[:4], selector[:]):
+		format := "selector 0x%x does not match 0x%x"
+		return nil, fmt.Errorf(format, returndata[:4], selector)
+	}
+	return returndata[4:], nil
+}
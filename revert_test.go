@@ -0,0 +1,105 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+// revertWithReason is the return data of a call that reverted with
+// Error("Not enough Ether provided.")
+var revertWithReason = hexDecode("" +
+	"08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"000000000000000000000000000000000000000000000000000000000000001a" +
+	"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000",
+)
+
+// revertWithPanic is the return data of a call that reverted with
+// Panic(0x11)
+var revertWithPanic = hexDecode("" +
+	"4e487b71" +
+	"0000000000000000000000000000000000000000000000000000000000000011",
+)
+
+func TestPanicReason(t *testing.T) {
+	for _, tc := range []struct {
+		code *big.Int
+		want string
+	}{
+		{code: big.NewInt(0x01), want: "assertion failed"},
+		{code: big.NewInt(0x11), want: "arithmetic overflow or underflow"},
+		{code: big.NewInt(0x12), want: "division or modulo by zero"},
+		{code: big.NewInt(0x32), want: "array index out of bounds"},
+		{code: big.NewInt(0x99), want: "unknown panic code 0x99"},
+		{code: big.NewInt(0x5), want: "unknown panic code 0x05"},
+		{code: nil, want: "unknown panic code"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			// when
+			got := abi.PanicReason(tc.code)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got, err := abi.DecodeRevertReason(revertWithReason)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "Not enough Ether provided.", got)
+	})
+
+	t.Run("wrong selector", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeRevertReason(revertWithPanic)
+		// then
+		assert.ErrorContains(t, err, "selector 0x4e487b71 does not match 0x08c379a0")
+	})
+
+	t.Run("bad reason", func(t *testing.T) {
+		// when
+		_, err := abi.DecodeRevertReason(revertWithReason[:40])
+		// then
+		assert.ErrorContains(t, err, "decoding reason")
+	})
+}
+
+func TestTryDecodeError(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		// when
+		got, err := abi.TryDecodeError(revertWithReason)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "Not enough Ether provided.", got)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		// when
+		got, err := abi.TryDecodeError(revertWithPanic)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "arithmetic overflow or underflow", got)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		// when
+		_, err := abi.TryDecodeError([]byte{0x08, 0xc3})
+		// then
+		assert.ErrorContains(t, err, "too short to have a selector")
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// when
+		_, err := abi.TryDecodeError([]byte{0xde, 0xad, 0xbe, 0xef})
+		// then
+		assert.ErrorContains(t, err, "unknown error selector 0xdeadbeef")
+	})
+}
@@ -0,0 +1,169 @@
+package abi_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+// roundTripCodec drives a single codec through the round trip harness.
+type roundTripCodec struct {
+	name    string
+	samples []any
+	encode  func(any) ([]byte, error)
+	decode  func([]byte) (any, error)
+}
+
+func newRoundTripCodec[T any](
+	name string,
+	encode func(T) ([]byte, error),
+	decode func([]byte) (T, error),
+	samples ...T,
+) roundTripCodec {
+	codec := roundTripCodec{
+		name:   name,
+		encode: func(v any) ([]byte, error) { return encode(v.(T)) },
+		decode: func(data []byte) (any, error) { return decode(data) },
+	}
+	for i := range samples {
+		codec.samples = append(codec.samples, samples[i])
+	}
+	return codec
+}
+
+func newSchemaCodec(typeName string, samples ...any) roundTripCodec {
+	t := abi.MustParseType(typeName)
+	return roundTripCodec{
+		name:    "schema " + typeName,
+		samples: samples,
+		encode: func(v any) ([]byte, error) {
+			return abi.EncodeValue(t, v)
+		},
+		decode: func(data []byte) (any, error) {
+			return abi.DecodeValue(data, t, abi.DecodeOptions{})
+		},
+	}
+}
+
+type uint64AndBytes struct {
+	Int   uint64
+	Bytes []byte
+}
+
+// Decoders always return non-nil slices, so samples use empty rather than
+// nil slices.
+var roundTripCodecs = []roundTripCodec{
+	newRoundTripCodec("uint64",
+		func(v uint64) ([]byte, error) { return abi.EncodeUint64(v), nil },
+		abi.DecodeUint64,
+		0, 1, 1<<63-1, 1<<64-1,
+	),
+	newRoundTripCodec("int64",
+		abi.EncodeInteger[int64],
+		abi.DecodeInteger[int64],
+		0, -1, 1<<63-1, -1<<63,
+	),
+	newRoundTripCodec("address",
+		func(v [20]byte) ([]byte, error) { return abi.EncodeAddress(v), nil },
+		abi.DecodeAddress,
+		abi.ZeroAddress, someAddress(), repeatAddress(0xff),
+	),
+	newRoundTripCodec("bytes",
+		abi.EncodeBytes,
+		abi.DecodeBytes,
+		[]byte{}, []byte{1}, bytes.Repeat([]byte{7}, 32), bytes.Repeat([]byte{9}, 33),
+	),
+	newRoundTripCodec("slice of bytes",
+		abi.EncodeSliceOfBytes,
+		abi.DecodeSliceOfBytes,
+		[][]byte{}, [][]byte{{}}, [][]byte{{1}, bytes.Repeat([]byte{2}, 40)},
+	),
+	newRoundTripCodec("fixed uint64 array",
+		func(v [3]uint64) ([]byte, error) {
+			return abi.EncodeFixedUint64Array(v[:]), nil
+		},
+		func(data []byte) ([3]uint64, error) {
+			var v [3]uint64
+			err := abi.DecodeFixedUint64ArrayInto(data, v[:])
+			return v, err
+		},
+		[3]uint64{}, [3]uint64{1, 2, 1<<64 - 1},
+	),
+	newRoundTripCodec("multicall",
+		abi.EncodeMulticall,
+		abi.DecodeMulticall,
+		[]abi.Call{}, twoCalls.native,
+	),
+	newRoundTripCodec("tuple",
+		func(v uint64AndBytes) ([]byte, error) {
+			return abi.EncodeTuple(
+				abi.EncodeTupleFuncUint64(v.Int),
+				abi.EncodeTupleFuncBytes(v.Bytes),
+			)
+		},
+		func(data []byte) (uint64AndBytes, error) {
+			var v uint64AndBytes
+			err := abi.DecodeTuple(data,
+				abi.DecodeTupleFuncUint64(&v.Int),
+				abi.DecodeTupleFuncBytes(&v.Bytes),
+			)
+			return v, err
+		},
+		uint64AndBytes{Bytes: []byte{}}, uint64AndBytes{Int: 3, Bytes: []byte("hi")},
+	),
+	newSchemaCodec("uint256",
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+	),
+	newSchemaCodec("int256",
+		big.NewInt(0),
+		big.NewInt(-1),
+		new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1)),
+	),
+	newSchemaCodec("int8", big.NewInt(-128), big.NewInt(127)),
+	newSchemaCodec("bool", false, true),
+	newSchemaCodec("bytes32", nZeros(32), bytes.Repeat([]byte{1}, 32)),
+	newSchemaCodec("string", "", "hello", "wörld"),
+	newSchemaCodec("uint64[]", []any{}, []any{big.NewInt(1), big.NewInt(2)}),
+	newSchemaCodec("(address,bytes)[]",
+		[]any{},
+		[]any{
+			[]any{someAddress(), []byte{}},
+			[]any{repeatAddress(0x22), []byte("data")},
+		},
+	),
+	newSchemaCodec("(uint8,(bool,string))",
+		[]any{big.NewInt(1), []any{true, "nested"}},
+	),
+	newSchemaCodec("bytes[2]", []any{[]byte{}, []byte("second")}),
+	newSchemaCodec("((bytes32[],uint256),address)[]", []any{}, orders.native),
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, codec := range roundTripCodecs {
+		t.Run(codec.name, func(t *testing.T) {
+			for _, sample := range codec.samples {
+				// when
+				encoded, err := codec.encode(sample)
+				require.NoError(t, err)
+
+				decoded, err := codec.decode(encoded)
+				require.NoError(t, err)
+
+				reencoded, err := codec.encode(decoded)
+				require.NoError(t, err)
+
+				// then
+				assert.Equal(t, sample, decoded)
+				assert.Equal(t, encoded, reencoded)
+			}
+		})
+	}
+}
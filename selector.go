@@ -0,0 +1,76 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Selectors of well-known ERC-20 functions.
+var (
+	// TransferSelector is the selector of transfer(address,uint256).
+	TransferSelector = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	// TransferFromSelector is the selector of
+	// transferFrom(address,address,uint256).
+	TransferFromSelector = [4]byte{0x23, 0xb8, 0x72, 0xdd}
+	// ApproveSelector is the selector of approve(address,uint256).
+	ApproveSelector = [4]byte{0x09, 0x5e, 0xa7, 0xb3}
+	// BalanceOfSelector is the selector of balanceOf(address).
+	BalanceOfSelector = [4]byte{0x70, 0xa0, 0x82, 0x31}
+)
+
+// MethodID returns the four-byte selector of a function, that is, the
+// first 4 bytes of the Keccak-256 hash of its canonical signature, for
+// example "transfer(address,uint256)".  The signature is hashed as given,
+// so it must not contain spaces or argument names.
+func MethodID(signature string) [4]byte {
+	hash := Keccak256([]byte(signature))
+	return [4]byte(hash[:4])
+}
+
+// VerifySelector checks that want is the selector of signature.  It is
+// intended for tests, to catch typos in hardcoded selectors and
+// signatures.
+func VerifySelector(signature string, want [4]byte) error {
+	got := MethodID(signature)
+	if got != want {
+		return fmt.Errorf("selector mismatch: got 0x%x want 0x%x", got, want)
+	}
+	return nil
+}
+
+// EncodeCall encodes the calldata of a call of the function with the
+// given signature, that is, the selector of the function followed by the
+// tuple of arguments encoded by args.  As for MethodID, the signature is
+// hashed as given and so must be canonical.
+func EncodeCall(signature string, args ...EncoderFunc) ([]byte, error) {
+	encodedArgs, err := EncodeTuple(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := MethodID(signature)
+	out := make([]byte, 0, len(selector)+len(encodedArgs))
+	out = append(out, selector[:]...)
+	return append(out, encodedArgs...), nil
+}
+
+// DecodeCall splits calldata produced by EncodeCall into the selector and
+// the encoded arguments, which can then be decoded with DecodeTuple once
+// the selector has been matched against known MethodID values.  It is the
+// inverse operation of EncodeCall and is equivalent to SplitCalldata.
+func DecodeCall(data []byte) (selector [4]byte, args []byte, err error) {
+	return SplitCalldata(data)
+}
+
+// SplitCalldata splits calldata into the selector of the called function
+// and the encoded arguments that follow it.  The returned arguments alias
+// calldata.
+func SplitCalldata(calldata []byte) ([4]byte, []byte, error) {
+	switch {
+	case len(calldata) == 0:
+		return [4]byte{}, nil, ErrEmptyInput
+	case len(calldata) < 4:
+		return [4]byte{}, nil, errors.New("calldata too short to have a selector")
+	}
+	return [4]byte(calldata[:4]), calldata[4:], nil
+}
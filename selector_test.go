@@ -0,0 +1,166 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestMethodID(t *testing.T) {
+	for _, tc := range []struct {
+		signature string
+		want      [4]byte
+	}{
+		{signature: "transfer(address,uint256)", want: abi.TransferSelector},
+		{signature: "transferFrom(address,address,uint256)", want: abi.TransferFromSelector},
+		{signature: "approve(address,uint256)", want: abi.ApproveSelector},
+		{signature: "balanceOf(address)", want: abi.BalanceOfSelector},
+		{signature: "Error(string)", want: abi.ErrorSelector},
+		{signature: "Panic(uint256)", want: abi.PanicSelector},
+		{
+			signature: "swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+			want:      [4]byte{0x38, 0xed, 0x17, 0x39},
+		},
+	} {
+		t.Run(tc.signature, func(t *testing.T) {
+			// when
+			got := abi.MethodID(tc.signature)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestVerifySelector(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		err := abi.VerifySelector("transfer(address,uint256)", abi.TransferSelector)
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("typo in signature", func(t *testing.T) {
+		// when
+		err := abi.VerifySelector("transfer(address,uint)", abi.TransferSelector)
+		// then
+		assert.ErrorContains(t, err, "selector mismatch: got 0x")
+		assert.ErrorContains(t, err, "want 0xa9059cbb")
+	})
+}
+
+func TestSplitCalldata(t *testing.T) {
+	t.Run("selector and arguments", func(t *testing.T) {
+		// given
+		calldata := append(abi.TransferSelector[:], abi.EncodeUint64(7)...)
+
+		// when
+		selector, args, err := abi.SplitCalldata(calldata)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, abi.TransferSelector, selector)
+		assert.Equal(t, abi.EncodeUint64(7), args)
+	})
+
+	t.Run("selector only", func(t *testing.T) {
+		// when
+		selector, args, err := abi.SplitCalldata(abi.BalanceOfSelector[:])
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, abi.BalanceOfSelector, selector)
+		assert.Empty(t, args)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		// when
+		_, _, err := abi.SplitCalldata([]byte{0xa9, 0x05, 0x9c})
+
+		// then
+		assert.ErrorContains(t, err, "calldata too short to have a selector")
+	})
+}
+
+func TestEncodeCall(t *testing.T) {
+	t.Run("transfer", func(t *testing.T) {
+		// given
+		// transfer(0xdac17f958d2ee523a2206206994597c13d831ec7, 1000000)
+		want := hexDecode("" +
+			"a9059cbb" +
+			"000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7" +
+			"00000000000000000000000000000000000000000000000000000000000f4240",
+		)
+		to := hexAddress("dac17f958d2ee523a2206206994597c13d831ec7")
+
+		// when
+		got, err := abi.EncodeCall("transfer(address,uint256)",
+			abi.EncodeTupleFuncAddress(to),
+			abi.EncodeTupleFuncUint64(1_000_000),
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("no arguments", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeCall("totalSupply()")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, hexDecode("18160ddd"), got)
+	})
+
+	t.Run("encoder error", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeCall("f(bytes4)", abi.EncodeTupleFuncFixedBytes(nZeros(5), 4))
+
+		// then
+		assert.ErrorContains(t, err, "does not fit in bytes4")
+	})
+}
+
+func TestDecodeCall(t *testing.T) {
+	t.Run("round trip with dispatch", func(t *testing.T) {
+		// given
+		to := hexAddress("dac17f958d2ee523a2206206994597c13d831ec7")
+		calldata, err := abi.EncodeCall("transfer(address,uint256)",
+			abi.EncodeTupleFuncAddress(to),
+			abi.EncodeTupleFuncUint64(42),
+		)
+		require.NoError(t, err)
+
+		// when
+		selector, args, err := abi.DecodeCall(calldata)
+		require.NoError(t, err)
+
+		var gotTo [20]byte
+		var gotAmount uint64
+		switch selector {
+		case abi.TransferSelector:
+			err = abi.DecodeTuple(args,
+				abi.DecodeTupleFuncAddress(&gotTo),
+				abi.DecodeTupleFuncUint64(&gotAmount),
+			)
+		default:
+			t.Fatalf("unexpected selector 0x%x", selector)
+		}
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, to, gotTo)
+		assert.Equal(t, uint64(42), gotAmount)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		// when
+		_, _, err := abi.DecodeCall([]byte{0xa9})
+
+		// then
+		assert.ErrorContains(t, err, "calldata too short to have a selector")
+	})
+}
@@ -0,0 +1,355 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	types map[string][]Type
+}{
+	types: map[string][]Type{},
+}
+
+// RegisterType teaches ParseType and ParseSignature about a named struct,
+// so that the name may be used in place of the tuple of its components.
+// Registering a name again replaces its components.  Names of elementary
+// types, such as uint256, always refer to the elementary type.
+func RegisterType(name string, components []Type) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.types[name] = append([]Type(nil), components...)
+}
+
+func lookupType(name string) (Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	components, ok := registry.types[name]
+	if !ok {
+		return Type{}, false
+	}
+	return TupleType(append([]Type(nil), components...)...), true
+}
+
+// ParseType parses a type from its name, for example "uint256",
+// "bytes[]" or "(address,bytes)[2]".
+func ParseType(s string) (Type, error) {
+	p := &sigParser{s: s}
+	t, err := p.parseType()
+	if err != nil {
+		return Type{}, err
+	}
+
+	p.skipSpaces()
+	if !p.done() {
+		return Type{}, fmt.Errorf("unexpected '%s' after type", p.s[p.pos:])
+	}
+	return t, nil
+}
+
+// MustParseType is like ParseType but panics if the type cannot be parsed.
+// It simplifies the declaration of types that are known to be valid.
+func MustParseType(s string) Type {
+	t, err := ParseType(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseSignature parses a function or event signature, for example
+// "transfer(address,uint256)", into its name and argument types.
+// Argument names and data locations, as found in human-readable ABIs,
+// are ignored, so "transfer(address to, uint256 amount)" is also valid.
+func ParseSignature(sig string) (string, []Type, error) {
+	open := strings.IndexByte(sig, '(')
+	if open < 0 {
+		return "", nil, errors.New("signature is missing '('")
+	}
+
+	name := strings.TrimSpace(sig[:open])
+	if !isIdentifier(name) {
+		return "", nil, fmt.Errorf("invalid name '%s'", name)
+	}
+
+	p := &sigParser{s: sig, pos: open}
+	types, err := p.parseList()
+	if err != nil {
+		return "", nil, err
+	}
+
+	p.skipSpaces()
+	if !p.done() {
+		return "", nil, fmt.Errorf("unexpected '%s' after arguments", p.s[p.pos:])
+	}
+	return name, types, nil
+}
+
+// CanonicalSignature assembles the canonical signature of a function or
+// event from its name and argument types, as hashed by MethodID and
+// EventID.  Spaces are removed, the aliases uint, int and byte become
+// uint256, int256 and bytes1, fixed and ufixed become fixed128x18 and
+// ufixed128x18, tuple(...) becomes (...) and registered struct names are
+// expanded to the tuple of their components.  Other names are kept as
+// given, so a typo shows up as a selector mismatch rather than an error.
+func CanonicalSignature(name string, argTypes ...string) string {
+	canonical := make([]string, len(argTypes))
+	for i, argType := range argTypes {
+		canonical[i] = canonicalType(argType)
+	}
+	return name + "(" + strings.Join(canonical, ",") + ")"
+}
+
+// canonicalType rewrites each name in a type, such as "tuple(uint,bool)[]",
+// to its canonical form.
+func canonicalType(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+		if !isIdentifierByte(s[i]) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && isIdentifierByte(s[i]) {
+			i++
+		}
+		word := s[start:i]
+		if word == "tuple" && strings.HasPrefix(strings.TrimLeft(s[i:], " \t"), "(") {
+			continue
+		}
+		b.WriteString(canonicalName(word))
+	}
+	return b.String()
+}
+
+// canonicalName returns the canonical form of a single name in a type.
+// Array lengths also pass through it, and are returned unchanged.
+func canonicalName(name string) string {
+	switch name {
+	case "fixed":
+		return "fixed128x18"
+	case "ufixed":
+		return "ufixed128x18"
+	}
+	if t, err := namedType(name); err == nil {
+		return t.String()
+	}
+	return name
+}
+
+// sigParser is a recursive descent parser for types and signatures.
+type sigParser struct {
+	s   string
+	pos int
+}
+
+func (p *sigParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *sigParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *sigParser) skipSpaces() {
+	for !p.done() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// parseList parses a parenthesized, comma separated list of types.
+func (p *sigParser) parseList() ([]Type, error) {
+	if p.peek() != '(' {
+		return nil, fmt.Errorf("expected '(' at position %d", p.pos)
+	}
+	p.pos++
+
+	types := []Type{}
+	p.skipSpaces()
+	if p.peek() == ')' {
+		p.pos++
+		return types, nil
+	}
+
+	for {
+		t, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+
+		// skip over argument names and data locations
+		for {
+			p.skipSpaces()
+			if p.done() || p.peek() == ',' || p.peek() == ')' {
+				break
+			}
+			if p.identifier() == "" {
+				return nil, fmt.Errorf("unexpected '%c' at position %d", p.peek(), p.pos)
+			}
+		}
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return types, nil
+		default:
+			return nil, errors.New("missing ')'")
+		}
+	}
+}
+
+// parseType parses a single type including any array suffixes.
+func (p *sigParser) parseType() (Type, error) {
+	p.skipSpaces()
+
+	var t Type
+	switch {
+	case p.peek() == '(':
+		components, err := p.parseList()
+		if err != nil {
+			return Type{}, err
+		}
+		t = TupleType(components...)
+	default:
+		name := p.identifier()
+		if name == "tuple" && p.peek() == '(' {
+			components, err := p.parseList()
+			if err != nil {
+				return Type{}, err
+			}
+			t = TupleType(components...)
+			break
+		}
+
+		var err error
+		t, err = namedType(name)
+		if err != nil {
+			return Type{}, err
+		}
+	}
+
+	for p.peek() == '[' {
+		end := strings.IndexByte(p.s[p.pos:], ']')
+		if end < 0 {
+			return Type{}, errors.New("missing ']'")
+		}
+		length := p.s[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+
+		if length == "" {
+			t = SliceType(t)
+			continue
+		}
+		n, err := strconv.Atoi(length)
+		if err != nil || n < 1 {
+			return Type{}, fmt.Errorf("invalid array length '%s'", length)
+		}
+		t = ArrayType(t, n)
+
+		// the length is bounded as it is parsed, so that no decoder is
+		// handed an array whose head does not fit in memory
+		if _, err := t.checkedHeadSize(); err != nil {
+			return Type{}, fmt.Errorf("invalid array length '%s': %w", length, err)
+		}
+	}
+
+	if err := t.validate(); err != nil {
+		return Type{}, fmt.Errorf("invalid type '%s': %w", t, err)
+	}
+	return t, nil
+}
+
+// identifier consumes and returns the identifier at the current position.
+func (p *sigParser) identifier() string {
+	start := p.pos
+	for !p.done() && isIdentifierByte(p.peek()) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' ||
+		('a' <= c && c <= 'z') ||
+		('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9')
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || ('0' <= s[0] && s[0] <= '9') {
+		return false
+	}
+	for i := range len(s) {
+		if !isIdentifierByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// namedType resolves the name of an elementary or registered type.
+func namedType(name string) (Type, error) {
+	switch {
+	case name == "":
+		return Type{}, errors.New("missing type")
+	case name == "bool":
+		return BoolType(), nil
+	case name == "address":
+		return AddressType(), nil
+	case name == "string":
+		return StringType(), nil
+	case name == "bytes":
+		return BytesType(), nil
+	case name == "uint":
+		return UintType(256), nil
+	case name == "int":
+		return IntType(256), nil
+	case name == "byte":
+		return FixedBytesType(1), nil
+	}
+
+	for _, elementary := range []struct {
+		prefix string
+		make   func(int) Type
+	}{
+		{prefix: "uint", make: UintType},
+		{prefix: "int", make: IntType},
+		{prefix: "bytes", make: FixedBytesType},
+	} {
+		size, ok := strings.CutPrefix(name, elementary.prefix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(size)
+		if err != nil || strconv.Itoa(n) != size {
+			continue
+		}
+		t := elementary.make(n)
+		if err := t.validate(); err != nil {
+			return Type{}, fmt.Errorf("invalid type '%s': %w", name, err)
+		}
+		return t, nil
+	}
+
+	t, ok := lookupType(name)
+	if !ok {
+		return Type{}, fmt.Errorf("unknown type '%s'; register it first", name)
+	}
+	return t, nil
+}
@@ -0,0 +1,231 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestParseType(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  abi.Type
+	}{
+		{input: "uint256", want: abi.UintType(256)},
+		{input: "int8", want: abi.IntType(8)},
+		{input: "bool", want: abi.BoolType()},
+		{input: "address", want: abi.AddressType()},
+		{input: "bytes32", want: abi.FixedBytesType(32)},
+		{input: "bytes", want: abi.BytesType()},
+		{input: "string", want: abi.StringType()},
+		{input: "uint", want: abi.UintType(256)},
+		{input: "int", want: abi.IntType(256)},
+		{input: "byte", want: abi.FixedBytesType(1)},
+		{input: "uint64[]", want: abi.SliceType(abi.UintType(64))},
+		{input: "bytes[3][]", want: abi.SliceType(abi.ArrayType(abi.BytesType(), 3))},
+		{
+			input: "(address,bytes)[]",
+			want:  abi.SliceType(abi.TupleType(abi.AddressType(), abi.BytesType())),
+		},
+		{
+			input: "tuple(uint8,(bool,string))",
+			want: abi.TupleType(
+				abi.UintType(8),
+				abi.TupleType(abi.BoolType(), abi.StringType()),
+			),
+		},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			// when
+			got, err := abi.ParseType(tc.input)
+			require.NoError(t, err)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: "missing type"},
+		{input: "uint7", want: "invalid type 'uint7'"},
+		{input: "bytes33", want: "invalid type 'bytes33'"},
+		{input: "uint256[0]", want: "invalid array length '0'"},
+		{
+			input: "uint256[576460752303423488]",
+			want:  "invalid array length '576460752303423488': array of 576460752303423488 elements too large",
+		},
+		{
+			input: "uint256[288230376151711744]",
+			want:  "invalid array length '288230376151711744': array of 288230376151711744 elements too large",
+		},
+		{input: "uint256[1073741824][1073741824]", want: "invalid array length '1073741824'"},
+		{input: "uint256[99999999999999999999]", want: "invalid array length '99999999999999999999'"},
+		{input: "uint256[", want: "missing ']'"},
+		{input: "(uint256", want: "missing ')'"},
+		{input: "uint256 extra", want: "unexpected 'extra' after type"},
+		{input: "Foo", want: "unknown type 'Foo'; register it first"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			// when
+			_, err := abi.ParseType(tc.input)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		name, types, err := abi.ParseSignature("transfer(address,uint256)")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "transfer", name)
+		assert.Equal(t, []abi.Type{abi.AddressType(), abi.UintType(256)}, types)
+	})
+
+	t.Run("no arguments", func(t *testing.T) {
+		// when
+		name, types, err := abi.ParseSignature("totalSupply()")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "totalSupply", name)
+		assert.Empty(t, types)
+	})
+
+	t.Run("human-readable arguments", func(t *testing.T) {
+		// given
+		sig := "execute(address target, bytes calldata data, (uint8 v, bytes32 r) sig)"
+		want := []abi.Type{
+			abi.AddressType(),
+			abi.BytesType(),
+			abi.TupleType(abi.UintType(8), abi.FixedBytesType(32)),
+		}
+		// when
+		name, types, err := abi.ParseSignature(sig)
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, "execute", name)
+		assert.Equal(t, want, types)
+	})
+
+	t.Run("missing parenthesis", func(t *testing.T) {
+		// when
+		_, _, err := abi.ParseSignature("transfer")
+		// then
+		assert.ErrorContains(t, err, "missing '('")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		// when
+		_, _, err := abi.ParseSignature("1transfer(address)")
+		// then
+		assert.ErrorContains(t, err, "invalid name '1transfer'")
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		// when
+		_, _, err := abi.ParseSignature("transfer(address)x")
+		// then
+		assert.ErrorContains(t, err, "unexpected 'x' after arguments")
+	})
+
+	t.Run("unknown named type", func(t *testing.T) {
+		// when
+		_, _, err := abi.ParseSignature("fill(Unregistered)")
+		// then
+		assert.ErrorContains(t, err, "unknown type 'Unregistered'; register it first")
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	t.Run("named struct", func(t *testing.T) {
+		// given
+		abi.RegisterType("TestOrder", []abi.Type{abi.AddressType(), abi.UintType(256)})
+		order := abi.TupleType(abi.AddressType(), abi.UintType(256))
+		// when
+		_, types, err := abi.ParseSignature("fill(TestOrder,TestOrder[] others)")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, []abi.Type{order, abi.SliceType(order)}, types)
+	})
+
+	t.Run("nested named struct", func(t *testing.T) {
+		// given
+		abi.RegisterType("TestInner", []abi.Type{abi.BoolType()})
+		abi.RegisterType("TestOuter", []abi.Type{abi.MustParseType("TestInner[]")})
+		want := abi.TupleType(abi.SliceType(abi.TupleType(abi.BoolType())))
+		// when
+		got, err := abi.ParseType("TestOuter")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("elementary names cannot be replaced", func(t *testing.T) {
+		// given
+		abi.RegisterType("uint256", []abi.Type{abi.BoolType()})
+		// when
+		got, err := abi.ParseType("uint256")
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, abi.UintType(256), got)
+	})
+}
+
+func TestCanonicalSignature(t *testing.T) {
+	t.Run("aliases yield the canonical selector", func(t *testing.T) {
+		// when
+		sig := abi.CanonicalSignature("transfer", "address", "uint")
+		// then
+		assert.Equal(t, "transfer(address,uint256)", sig)
+		assert.Equal(t, abi.TransferSelector, abi.MethodID(sig))
+		assert.Equal(t,
+			abi.MethodID(abi.CanonicalSignature("transfer", "address", "uint256")),
+			abi.MethodID(sig),
+		)
+	})
+
+	for _, tc := range []struct {
+		name     string
+		argTypes []string
+		want     string
+	}{
+		{name: "no arguments", argTypes: nil, want: "f()"},
+		{name: "int and byte", argTypes: []string{"int", "byte[]"}, want: "f(int256,bytes1[])"},
+		{
+			name:     "fixed point defaults",
+			argTypes: []string{"fixed", "ufixed[2]"},
+			want:     "f(fixed128x18,ufixed128x18[2])",
+		},
+		{name: "explicit fixed point", argTypes: []string{"fixed64x10"}, want: "f(fixed64x10)"},
+		{name: "spaces", argTypes: []string{" uint [ ] "}, want: "f(uint256[])"},
+		{
+			name:     "tuples",
+			argTypes: []string{"tuple(uint, (bool,byte))[]", "(address)"},
+			want:     "f((uint256,(bool,bytes1))[],(address))",
+		},
+		{name: "unknown names are kept", argTypes: []string{"Foo"}, want: "f(Foo)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got := abi.CanonicalSignature("f", tc.argTypes...)
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("registered structs are expanded", func(t *testing.T) {
+		// given
+		abi.RegisterType("TestCanonicalOrder", []abi.Type{abi.AddressType(), abi.UintType(256)})
+		// when
+		got := abi.CanonicalSignature("fill", "TestCanonicalOrder[]")
+		// then
+		assert.Equal(t, "fill((address,uint256)[])", got)
+	})
+}
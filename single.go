@@ -0,0 +1,78 @@
+package abi
+
+import (
+	"math/big"
+)
+
+// The EncodeSingle functions encode a value as a tuple with the value as
+// its only field, which matches abi.encode(x) in solidity.  For static
+// types, such as uint256, this is the same as the bare encoding of the
+// value.  For dynamic types, such as bytes, the encoding of the value is
+// preceded by a 0x20 offset word, unlike the bare encoding produced by
+// functions like EncodeBytes.
+
+// EncodeSingleUint256 encodes v as abi.encode(uint256(v)).  It is the
+// inverse operation of DecodeSingleUint256.
+func EncodeSingleUint256(v *big.Int) ([]byte, error) {
+	return EncodeValue(UintType(256), v)
+}
+
+// DecodeSingleUint256 decodes the output of abi.encode(uint256).  It is
+// the inverse operation of EncodeSingleUint256.
+func DecodeSingleUint256(data []byte) (*big.Int, error) {
+	v, err := DecodeValue(data, UintType(256), DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*big.Int), nil
+}
+
+// EncodeSingleAddress encodes addr as abi.encode(address(addr)).  It is
+// the inverse operation of DecodeSingleAddress.
+func EncodeSingleAddress(addr [20]byte) []byte {
+	return EncodeAddress(addr)
+}
+
+// DecodeSingleAddress decodes the output of abi.encode(address).  It is
+// the inverse operation of EncodeSingleAddress.
+func DecodeSingleAddress(data []byte) ([20]byte, error) {
+	v, err := DecodeValue(data, AddressType(), DecodeOptions{})
+	if err != nil {
+		return [20]byte{}, err
+	}
+	return v.([20]byte), nil
+}
+
+// EncodeSingleBytes encodes v as abi.encode(bytes(v)), that is, the
+// encoding of v preceded by its offset.  It is the inverse operation of
+// DecodeSingleBytes.
+func EncodeSingleBytes(v []byte) ([]byte, error) {
+	return EncodeValue(BytesType(), v)
+}
+
+// DecodeSingleBytes decodes the output of abi.encode(bytes).  It is the
+// inverse operation of EncodeSingleBytes.
+func DecodeSingleBytes(data []byte) ([]byte, error) {
+	v, err := DecodeValue(data, BytesType(), DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// EncodeSingleString encodes s as abi.encode(string(s)), that is, the
+// encoding of s preceded by its offset.  It is the inverse operation of
+// DecodeSingleString.
+func EncodeSingleString(s string) ([]byte, error) {
+	return EncodeValue(StringType(), s)
+}
+
+// DecodeSingleString decodes the output of abi.encode(string).  It is the
+// inverse operation of EncodeSingleString.
+func DecodeSingleString(data []byte) (string, error) {
+	v, err := DecodeValue(data, StringType(), DecodeOptions{})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
@@ -0,0 +1,100 @@
+package abi_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeDecodeSingleUint256(t *testing.T) {
+	t.Run("matches bare encoding", func(t *testing.T) {
+		// given
+		v := big.NewInt(42)
+
+		// when
+		got, err := abi.EncodeSingleUint256(v)
+		require.NoError(t, err)
+		decoded, err := abi.DecodeSingleUint256(got)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, abi.EncodeUint64(42), got)
+		assert.Equal(t, v, decoded)
+	})
+
+	t.Run("negative value", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeSingleUint256(big.NewInt(-1))
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeDecodeSingleAddress(t *testing.T) {
+	// given
+	addr := hexAddress("dac17f958d2ee523a2206206994597c13d831ec7")
+
+	// when
+	got := abi.EncodeSingleAddress(addr)
+	decoded, err := abi.DecodeSingleAddress(got)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, abi.EncodeAddress(addr), got)
+	assert.Equal(t, addr, decoded)
+}
+
+func TestEncodeDecodeSingleBytes(t *testing.T) {
+	t.Run("offset wrapper", func(t *testing.T) {
+		// given
+		v := []byte("hello")
+		bare, err := abi.EncodeBytes(v)
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.EncodeSingleBytes(v)
+		require.NoError(t, err)
+		decoded, err := abi.DecodeSingleBytes(got)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, append(abi.EncodeUint64(32), bare...), got)
+		assert.Equal(t, v, decoded)
+	})
+
+	t.Run("bare encoding", func(t *testing.T) {
+		// given
+		bare, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+
+		// when
+		_, err = abi.DecodeSingleBytes(bare)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeDecodeSingleString(t *testing.T) {
+	// given
+	// abi.encode("hello")
+	want := hexDecode("" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"68656c6c6f000000000000000000000000000000000000000000000000000000",
+	)
+
+	// when
+	got, err := abi.EncodeSingleString("hello")
+	require.NoError(t, err)
+	decoded, err := abi.DecodeSingleString(got)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, want, got)
+	assert.Equal(t, "hello", decoded)
+}
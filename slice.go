@@ -0,0 +1,88 @@
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// EncodeSlice encodes a dynamic array whose elements are encoded by
+// elements.  After the slice header and the element count, the elements
+// are laid out like the fields of a tuple, so static elements are stored
+// inline and dynamic elements are referenced by offsets.  This allows
+// encoding slices of any element type, for example, an address[] with
+// EncodeTupleFuncAddress.  It is the inverse operation of DecodeSlice.
+func EncodeSlice(elements []EncoderFunc) ([]byte, error) {
+	results, err := runEncoders(elements)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := assembleTuple(results)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 64+len(body))
+	out = append(out, precomputedSliceHeader...)
+	out = append(out, EncodeUint64(uint64(len(elements)))...)
+	return append(out, body...), nil
+}
+
+// DecodeSlice decodes a dynamic array, calling makeDecoder for the index
+// of each element to get the decoder of that element, and returns the
+// number of elements.  Each element must take up a single head slot, that
+// is, it must either be dynamic or fit in one word, which holds for the
+// decoders of this package that return a single DecoderFunc.  The
+// decoders of all elements are made before any of them is run.  It is the
+// inverse operation of EncodeSlice.
+func DecodeSlice(data []byte, makeDecoder func(i int) DecoderFunc) (int, error) {
+	return DecodeSliceCtx(context.Background(), data, makeDecoder)
+}
+
+// DecodeSliceCtx decodes a dynamic array like DecodeSlice, but aborts with
+// ctx.Err() when ctx is cancelled or its deadline is exceeded, which is
+// checked every few elements as by DecodeTupleCtx.
+func DecodeSliceCtx(
+	ctx context.Context,
+	data []byte,
+	makeDecoder func(i int) DecoderFunc,
+) (int, error) {
+	switch {
+	case len(data) == 0:
+		return 0, ErrEmptyInput
+	case len(data) < 64:
+		return 0, errors.New("not long enough to have a head")
+	case !sliceEqual(data[:32], precomputedSliceHeader):
+		return 0, errors.New("not a slice type")
+	}
+
+	eltCount, err := DecodeUint64(data[32:64])
+	if err != nil {
+		return 0, fmt.Errorf("decoding element count, %w", err)
+	}
+
+	elems := data[64:]
+	if eltCount > uint64(len(elems)/32) {
+		return 0, fmt.Errorf("tail too short for %d elements", eltCount)
+	}
+	if eltCount == 0 {
+		return 0, nil
+	}
+
+	decoders := make([]DecoderFunc, eltCount)
+	for i := range decoders {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		decoders[i] = makeDecoder(i)
+	}
+
+	err = decodeTuple(ctx, elems, decoders)
+	if err != nil {
+		return 0, err
+	}
+	return int(eltCount), nil
+}
@@ -0,0 +1,212 @@
+package abi_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeSlice(t *testing.T) {
+	t.Run("static elements", func(t *testing.T) {
+		// given
+		elements := make([]abi.EncoderFunc, len(getOwners.native))
+		for i := range getOwners.native {
+			elements[i] = abi.EncodeTupleFuncAddress(getOwners.native[i])
+		}
+
+		// when
+		got, err := abi.EncodeSlice(elements)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, getOwners.encoded, got)
+	})
+
+	t.Run("dynamic elements", func(t *testing.T) {
+		// given
+		input := [][]byte{[]byte("hello"), {}, nZeros(40)}
+		elements := make([]abi.EncoderFunc, len(input))
+		for i := range input {
+			elements[i] = abi.EncodeTupleFuncBytes(input[i])
+		}
+		want, err := abi.EncodeSliceOfBytes(input)
+		require.NoError(t, err)
+
+		// when
+		got, err := abi.EncodeSlice(elements)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// when
+		got, err := abi.EncodeSlice(nil)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, append(abi.SliceHeader(), abi.EncodeUint64(0)...), got)
+	})
+
+	t.Run("encoder error", func(t *testing.T) {
+		// when
+		_, err := abi.EncodeSlice([]abi.EncoderFunc{
+			abi.EncodeTupleFuncFixedBytes(nZeros(33), 32),
+		})
+		// then
+		assert.ErrorContains(t, err, "does not fit in bytes32")
+	})
+}
+
+func TestDecodeSlice(t *testing.T) {
+	t.Run("static elements", func(t *testing.T) {
+		// given
+		input := []bool{true, false, true}
+		elements := make([]abi.EncoderFunc, len(input))
+		for i := range input {
+			elements[i] = abi.EncodeTupleFuncBool(input[i])
+		}
+		encoded, err := abi.EncodeSlice(elements)
+		require.NoError(t, err)
+
+		// when
+		got := make([]bool, 3)
+		n, err := abi.DecodeSlice(encoded, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncBool(&got[i])
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, input, got)
+	})
+
+	t.Run("dynamic elements", func(t *testing.T) {
+		// given
+		want := [][]byte{[]byte("hello"), {}, nZeros(40)}
+		encoded, err := abi.EncodeSliceOfBytes(want)
+		require.NoError(t, err)
+
+		// when
+		// the decoders are made before any is run, so each element is
+		// decoded into its own allocation rather than into a growing slice
+		var elems []*[]byte
+		n, err := abi.DecodeSlice(encoded, func(int) abi.DecoderFunc {
+			elem := new([]byte)
+			elems = append(elems, elem)
+			return abi.DecodeTupleFuncBytes(elem)
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		for i := range want {
+			assert.Equal(t, want[i], *elems[i])
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// given
+		encoded := append(abi.SliceHeader(), abi.EncodeUint64(0)...)
+		// when
+		n, err := abi.DecodeSlice(encoded, func(int) abi.DecoderFunc {
+			t.Fatal("unexpected call")
+			return nil
+		})
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		encoded := append(abi.EncodeUint64(64), abi.EncodeUint64(0)...)
+		// when
+		_, err := abi.DecodeSlice(encoded, nil)
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("too many elements", func(t *testing.T) {
+		// given
+		encoded := append(abi.SliceHeader(), abi.EncodeUint64(2)...)
+		encoded = append(encoded, abi.EncodeUint64(1)...)
+		// when
+		_, err := abi.DecodeSlice(encoded, nil)
+		// then
+		assert.ErrorContains(t, err, "tail too short for 2 elements")
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		// given
+		encoded := abi.EncodeSliceOfUint64([]uint64{1, 2})
+		// when
+		got := make([]bool, 2)
+		_, err := abi.DecodeSlice(encoded, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncBool(&got[i])
+		})
+		// then
+		assert.ErrorContains(t, err, "invalid bool value")
+	})
+}
+
+func TestDecodeSliceCtx(t *testing.T) {
+	// a large slice of bytes
+	const elements = 10_000
+	input := make([][]byte, elements)
+	for i := range input {
+		input[i] = bytes.Repeat([]byte{byte(i)}, 40)
+	}
+	encoded, err := abi.EncodeSliceOfBytes(input)
+	require.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		got := make([][]byte, elements)
+		n, err := abi.DecodeSliceCtx(context.Background(), encoded, func(i int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncBytes(&got[i])
+		})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, elements, n)
+		assert.Equal(t, input, got)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// when
+		_, err := abi.DecodeSliceCtx(ctx, encoded, func(int) abi.DecoderFunc {
+			return abi.DecodeTupleFuncBytes(new([]byte))
+		})
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancelled while decoding", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		count := 0
+		// when
+		_, err := abi.DecodeSliceCtx(ctx, encoded, func(int) abi.DecoderFunc {
+			decode := abi.DecodeTupleFuncBytes(new([]byte))
+			return func(cur, full []byte) error {
+				count++
+				if count == 100 {
+					cancel()
+				}
+				return decode(cur, full)
+			}
+		})
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, count, 200)
+	})
+}
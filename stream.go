@@ -0,0 +1,190 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EncodeBytesChunked is a streaming alternative to EncodeBytes for large
+// payloads.  It produces the same encoding as EncodeBytes, but rather than
+// building it in memory, it passes it to fn in pieces: first the 32-byte
+// length word, then the content of v in chunks of at most chunk bytes and
+// finally the padding.  The pieces passed to fn may alias v and so fn must
+// not retain them.
+func EncodeBytesChunked(v []byte, chunk int, fn func([]byte) error) error {
+	if chunk <= 0 {
+		return fmt.Errorf("invalid chunk size %d", chunk)
+	}
+
+	emitted := 0
+	emit := func(piece []byte) error {
+		emitted += len(piece)
+		return fn(piece)
+	}
+
+	vLen := len(v)
+	err := emit(EncodeUint64(uint64(vLen)))
+	if err != nil {
+		return fmt.Errorf("emitting length, %w", err)
+	}
+
+	for start := 0; start < vLen; start += chunk {
+		end := min(start+chunk, vLen)
+		err := emit(v[start:end])
+		if err != nil {
+			return fmt.Errorf("emitting content at %d, %w", start, err)
+		}
+	}
+
+	alignedLen, err := nextMultipleOf32(vLen)
+	if err != nil {
+		return err
+	}
+	if padLen := alignedLen - vLen; padLen > 0 {
+		// the padding is fresh for every call, so that fn cannot change
+		// the padding of later encodings
+		err := emit(make([]byte, padLen))
+		if err != nil {
+			return fmt.Errorf("emitting padding, %w", err)
+		}
+	}
+
+	if want := 32 + alignedLen; emitted != want {
+		return fmt.Errorf("emitted %d bytes but expected %d", emitted, want)
+	}
+	return nil
+}
+
+// DecodeBytesTo is a streaming alternative to DecodeBytes for large
+// payloads.  It validates abiEncoded just like DecodeBytes, but rather than
+// returning a copy of the data, it writes the data to w and returns the
+// number of bytes written.
+func DecodeBytesTo(w io.Writer, abiEncoded []byte) (int, error) {
+	if len(abiEncoded) == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	data, err := bytesData(abiEncoded, &DecodeOptions{}, DecodeUint64)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("writing data, %w", err)
+	}
+	return n, nil
+}
+
+// Decoder decodes the elements of a tuple from an io.Reader, reading the
+// head one 32-byte word at a time as the elements are decoded, so that the
+// encoding does not need to be buffered as a whole.  Elements are decoded
+// in order by calling the method of the type of each element.
+//
+// Static elements, such as uint64, only require reading forward, so any
+// io.Reader can be used for tuples of static elements.  The data of
+// dynamic elements, such as bytes, is stored in the tail and is found by
+// following its offset, which requires the reader to be an io.ReadSeeker.
+// Offsets are relative to the position of the reader when the first
+// element was decoded.
+type Decoder struct {
+	r io.Reader
+	// headRead is the number of bytes of the head read so far.
+	headRead int64
+}
+
+// NewDecoder creates a Decoder that reads a tuple from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// readWord reads the next word of the head.
+func (d *Decoder) readWord() ([]byte, error) {
+	word := make([]byte, 32)
+	n, err := io.ReadFull(d.r, word)
+	d.headRead += int64(n)
+	if err != nil {
+		return nil, fmt.Errorf("reading word, %w", err)
+	}
+	return word, nil
+}
+
+// Uint64 decodes the next element of the tuple as a uint64.
+func (d *Decoder) Uint64() (uint64, error) {
+	word, err := d.readWord()
+	if err != nil {
+		return 0, err
+	}
+	return DecodeUint64(word)
+}
+
+// Bytes decodes the next element of the tuple as bytes.  The reader must
+// be an io.ReadSeeker, which is left positioned at the next element of the
+// head.  The data is validated just like DecodeBytes, and is read as it
+// arrives, so a length that exceeds the available data fails without
+// allocating that length up front.
+func (d *Decoder) Bytes() ([]byte, error) {
+	rs, ok := d.r.(io.ReadSeeker)
+	if !ok {
+		return nil, errors.New("decoding bytes requires an io.ReadSeeker")
+	}
+
+	word, err := d.readWord()
+	if err != nil {
+		return nil, err
+	}
+	offset, err := DecodeUint64(word)
+	if err != nil {
+		return nil, fmt.Errorf("decoding offset, %w", err)
+	}
+
+	// the tuple starts where the reader was before the head was read
+	next, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("seeking, %w", err)
+	}
+	start := next - d.headRead
+	if offset > uint64(math.MaxInt64-start) {
+		return nil, fmt.Errorf("offset %d out of bounds", offset)
+	}
+	if _, err := rs.Seek(start+int64(offset), io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seeking to data, %w", err)
+	}
+
+	data, err := readBytes(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rs.Seek(next, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seeking to head, %w", err)
+	}
+	return data, nil
+}
+
+// readBytes reads the length word and padded data of bytes from r and
+// decodes them.
+func readBytes(r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, 32); err != nil {
+		return nil, fmt.Errorf("reading length, %w", err)
+	}
+	length, err := DecodeUint64(buf.Bytes())
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding data length, %w", err)
+	case length > math.MaxInt64-31:
+		return nil, fmt.Errorf("length in head is out of range")
+	}
+
+	// the buffer grows as the data is read, rather than to the length
+	// claimed by the encoding, which may exceed the available data
+	alignedLen := int64(length+31) / 32 * 32
+	if _, err := io.CopyN(&buf, r, alignedLen); err != nil {
+		return nil, fmt.Errorf("reading data, %w", err)
+	}
+	return DecodeBytes(buf.Bytes())
+}
@@ -0,0 +1,259 @@
+package abi_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestEncodeBytesChunked(t *testing.T) {
+	input := bytes.Repeat([]byte("0123456789"), 10)
+
+	for _, chunk := range []int{1, 7, 32, 100, 1000} {
+		t.Run(fmt.Sprintf("chunk size %d", chunk), func(t *testing.T) {
+			// given
+			want, err := abi.EncodeBytes(input)
+			require.NoError(t, err)
+
+			// when
+			got := bytes.Buffer{}
+			err = abi.EncodeBytesChunked(input, chunk, func(piece []byte) error {
+				assert.LessOrEqual(t, len(piece), max(chunk, 32))
+				got.Write(piece)
+				return nil
+			})
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, want, got.Bytes())
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		// when
+		got := bytes.Buffer{}
+		err := abi.EncodeBytesChunked([]byte{}, 8, func(piece []byte) error {
+			got.Write(piece)
+			return nil
+		})
+		require.NoError(t, err)
+		// then
+		assert.Equal(t, nZeros(32), got.Bytes())
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		// when
+		err := abi.EncodeBytesChunked(input, 0, func([]byte) error { return nil })
+		// then
+		assert.ErrorContains(t, err, "invalid chunk size 0")
+	})
+
+	t.Run("fn fails", func(t *testing.T) {
+		// given
+		calls := 0
+		fnErr := errors.New("fn-error")
+		// when
+		err := abi.EncodeBytesChunked(input, 8, func([]byte) error {
+			calls++
+			if calls == 2 {
+				return fnErr
+			}
+			return nil
+		})
+		// then
+		assert.ErrorIs(t, err, fnErr)
+		assert.ErrorContains(t, err, "emitting content at 0")
+	})
+
+	t.Run("fn writes to the padding", func(t *testing.T) {
+		// given
+		err := abi.EncodeBytesChunked([]byte{1}, 8, func(piece []byte) error {
+			for i := range piece {
+				piece[i] = 0xff
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		want, err := abi.EncodeBytes([]byte{1})
+		require.NoError(t, err)
+
+		// when
+		got := bytes.Buffer{}
+		err = abi.EncodeBytesChunked([]byte{1}, 8, func(piece []byte) error {
+			got.Write(piece)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, want, got.Bytes())
+	})
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestDecodeBytesTo(t *testing.T) {
+	for name, input := range map[string][]byte{
+		"empty":       {},
+		"a-few-bytes": []byte("hello"),
+		"word":        bytes.Repeat([]byte{1}, 32),
+		"large":       bytes.Repeat([]byte("0123456789"), 1000),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			encoded, err := abi.EncodeBytes(input)
+			require.NoError(t, err)
+			want, err := abi.DecodeBytes(encoded)
+			require.NoError(t, err)
+
+			// when
+			got := bytes.Buffer{}
+			n, err := abi.DecodeBytesTo(&got, encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, len(want), n)
+			assert.True(t, bytes.Equal(want, got.Bytes()))
+		})
+	}
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		// given
+		encoded, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		encoded[63] = 1
+		// when
+		got := bytes.Buffer{}
+		_, err = abi.DecodeBytesTo(&got, encoded)
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+		assert.Zero(t, got.Len())
+	})
+
+	t.Run("writer fails", func(t *testing.T) {
+		// given
+		encoded, err := abi.EncodeBytes([]byte("hello"))
+		require.NoError(t, err)
+		writeErr := errors.New("write-error")
+		// when
+		_, err = abi.DecodeBytesTo(failingWriter{err: writeErr}, encoded)
+		// then
+		assert.ErrorIs(t, err, writeErr)
+		assert.ErrorContains(t, err, "writing data")
+	})
+}
+
+func TestDecoder(t *testing.T) {
+	encoded, err := abi.NewTupleEncoder().
+		Uint64(7).
+		Bytes([]byte("hello")).
+		Uint64(9).
+		Bytes(bytes.Repeat([]byte{0xab}, 40)).
+		Encode()
+	require.NoError(t, err)
+
+	t.Run("mixed elements", func(t *testing.T) {
+		// given
+		// the tuple does not start at the beginning of the reader
+		r := bytes.NewReader(append([]byte("prefix"), encoded...))
+		_, err := r.Seek(6, io.SeekStart)
+		require.NoError(t, err)
+		d := abi.NewDecoder(r)
+
+		// when
+		a, err := d.Uint64()
+		require.NoError(t, err)
+		b, err := d.Bytes()
+		require.NoError(t, err)
+		c, err := d.Uint64()
+		require.NoError(t, err)
+		e, err := d.Bytes()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, uint64(7), a)
+		assert.Equal(t, []byte("hello"), b)
+		assert.Equal(t, uint64(9), c)
+		assert.Equal(t, bytes.Repeat([]byte{0xab}, 40), e)
+	})
+
+	t.Run("static elements from a reader", func(t *testing.T) {
+		// given
+		r := struct{ io.Reader }{bytes.NewReader(encoded)}
+		d := abi.NewDecoder(r)
+
+		// when
+		a, err := d.Uint64()
+		require.NoError(t, err)
+		_, err = d.Bytes()
+
+		// then
+		assert.Equal(t, uint64(7), a)
+		assert.ErrorContains(t, err, "decoding bytes requires an io.ReadSeeker")
+	})
+
+	t.Run("truncated head", func(t *testing.T) {
+		// given
+		d := abi.NewDecoder(bytes.NewReader(encoded[:20]))
+
+		// when
+		_, err := d.Uint64()
+
+		// then
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("length exceeds data", func(t *testing.T) {
+		// given
+		// a length of 2^40 that is not backed by data must fail rather
+		// than allocate
+		input := append(abi.EncodeUint64(32), abi.EncodeUint64(1<<40)...)
+		input = append(input, nZeros(32)...)
+		d := abi.NewDecoder(bytes.NewReader(input))
+
+		// when
+		_, err := d.Bytes()
+
+		// then
+		assert.ErrorContains(t, err, "reading data")
+	})
+
+	t.Run("invalid padding", func(t *testing.T) {
+		// given
+		input := append([]byte{}, encoded...)
+		input[len(input)-1] = 1
+		d := abi.NewDecoder(bytes.NewReader(input))
+
+		// when
+		_, err := d.Uint64()
+		require.NoError(t, err)
+		_, err = d.Bytes()
+		require.NoError(t, err)
+		_, err = d.Uint64()
+		require.NoError(t, err)
+		_, err = d.Bytes()
+
+		// then
+		assert.ErrorContains(t, err, "padding contains non-zero values")
+	})
+
+	t.Run("offset past the end", func(t *testing.T) {
+		// given
+		d := abi.NewDecoder(bytes.NewReader(abi.EncodeUint64(1024)))
+
+		// when
+		_, err := d.Bytes()
+
+		// then
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
@@ -0,0 +1,115 @@
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DecodeSliceOfTuplesFunc decodes a slice of tuples, such as the return
+// value of a function returning MyStruct[], one element at a time.  For
+// each element, perElement configures a fresh TupleDecoder with the
+// targets for the fields of that element, then the element is decoded and
+// decoding moves on to the next element.  Since no element is retained,
+// memory use is bounded by a single element, and perElement can also be
+// used to report progress.  An error returned by perElement aborts
+// decoding.
+//
+// Whether the tuples are static, and so stored inline, or dynamic, and so
+// referenced by offsets, is taken from the decoder of the first element.
+// The decoders of the other elements must have the same layout, that is,
+// be dynamic alike and take up the same number of head slots, as all
+// elements of a slice have the same type.
+func DecodeSliceOfTuplesFunc(
+	abiEncoded []byte,
+	perElement func(index int, d *TupleDecoder) error,
+) error {
+	return DecodeSliceOfTuplesFuncCtx(context.Background(), abiEncoded, perElement)
+}
+
+// DecodeSliceOfTuplesFuncCtx decodes a slice of tuples like
+// DecodeSliceOfTuplesFunc, but aborts with ctx.Err() when ctx is cancelled
+// or its deadline is exceeded, which is checked every few elements and
+// within the fields of each element as by DecodeTupleCtx.
+func DecodeSliceOfTuplesFuncCtx(
+	ctx context.Context,
+	abiEncoded []byte,
+	perElement func(index int, d *TupleDecoder) error,
+) error {
+	switch {
+	case len(abiEncoded) == 0:
+		return ErrEmptyInput
+	case len(abiEncoded) < 64:
+		return errors.New("not long enough to have a head")
+	case !sliceEqual(abiEncoded[:32], precomputedSliceHeader):
+		return errors.New("not a slice type")
+	}
+
+	eltCount, err := DecodeUint64(abiEncoded[32:64])
+	if err != nil {
+		return fmt.Errorf("decoding element count, %w", err)
+	}
+
+	// offsets of dynamic elements and static elements themselves are
+	// relative to the region following the element count
+	elems := abiEncoded[64:]
+	if eltCount > uint64(len(elems)/32) {
+		return fmt.Errorf("tail too short for %d elements", eltCount)
+	}
+
+	var dynamic bool
+	var slots int
+	for i := range int(eltCount) {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		d := NewTupleDecoder()
+		if err := perElement(i, d); err != nil {
+			return fmt.Errorf("element %d, %w", i, err)
+		}
+		switch {
+		case i == 0:
+			dynamic, slots = d.dynamic, len(d.decoders)
+		case d.dynamic != dynamic || len(d.decoders) != slots:
+			return fmt.Errorf("element %d, layout differs from element 0", i)
+		}
+
+		region, err := tupleElement(elems, i, dynamic, slots)
+		if err != nil {
+			return fmt.Errorf("decoding element %d, %w", i, err)
+		}
+		if err := decodeTuple(ctx, region, d.decoders); err != nil {
+			return fmt.Errorf("decoding element %d, %w", i, err)
+		}
+	}
+	return nil
+}
+
+// tupleElement returns the region of elems holding the i-th tuple, where
+// static tuples take up slots words inline and dynamic tuples are
+// referenced by an offset.
+func tupleElement(elems []byte, i int, dynamic bool, slots int) ([]byte, error) {
+	if !dynamic {
+		start, end := i*slots*32, (i+1)*slots*32
+		if end > len(elems) {
+			return nil, errors.New("end is out of bounds")
+		}
+		return elems[start:end], nil
+	}
+
+	word, err := ReadWord(elems, i)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := DecodeUint64(word)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("decoding offset, %w", err)
+	case offset > uint64(len(elems)):
+		return nil, errors.New("offset out of bounds")
+	}
+	return elems[offset:], nil
+}
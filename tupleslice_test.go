@@ -0,0 +1,230 @@
+package abi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestDecodeSliceOfTuplesFunc(t *testing.T) {
+	t.Run("dynamic tuples", func(t *testing.T) {
+		// given
+		want := []uint64AndBytes{
+			{Int: 1, Bytes: []byte("first")},
+			{Int: 2, Bytes: []byte{}},
+			{Int: 3, Bytes: []byte("a value longer than a single word")},
+		}
+		values := make([]any, len(want))
+		for i := range want {
+			values[i] = []any{want[i].Int, want[i].Bytes}
+		}
+		input, err := abi.EncodeValue(abi.MustParseType("(uint64,bytes)[]"), values)
+		require.NoError(t, err)
+
+		// when
+		var got []uint64AndBytes
+		err = abi.DecodeSliceOfTuplesFunc(input, func(i int, d *abi.TupleDecoder) error {
+			got = append(got, uint64AndBytes{})
+			d.Uint64(&got[i].Int).Bytes(&got[i].Bytes)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("static tuples", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeValue(abi.MustParseType("(uint64,uint64[2])[]"), []any{
+			[]any{1, []any{2, 3}},
+			[]any{4, []any{5, 6}},
+		})
+		require.NoError(t, err)
+
+		// when
+		var got [][3]uint64
+		err = abi.DecodeSliceOfTuplesFunc(input, func(i int, d *abi.TupleDecoder) error {
+			got = append(got, [3]uint64{})
+			d.Uint64(&got[i][0]).FixedUint64Array(got[i][1:])
+			return nil
+		})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, [][3]uint64{{1, 2, 3}, {4, 5, 6}}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// given
+		input := append(abi.SliceHeader(), abi.EncodeUint64(0)...)
+		// when
+		calls := 0
+		err := abi.DecodeSliceOfTuplesFunc(input, func(int, *abi.TupleDecoder) error {
+			calls++
+			return nil
+		})
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, calls)
+	})
+
+	t.Run("callback aborts", func(t *testing.T) {
+		// given
+		input, err := abi.EncodeValue(abi.MustParseType("(uint64)[]"), []any{
+			[]any{1}, []any{2}, []any{3},
+		})
+		require.NoError(t, err)
+		abort := errors.New("abort")
+
+		// when
+		var seen []int
+		err = abi.DecodeSliceOfTuplesFunc(input, func(i int, d *abi.TupleDecoder) error {
+			seen = append(seen, i)
+			if i == 1 {
+				return abort
+			}
+			var v uint64
+			d.Uint64(&v)
+			return nil
+		})
+
+		// then
+		assert.ErrorIs(t, err, abort)
+		assert.ErrorContains(t, err, "element 1")
+		assert.Equal(t, []int{0, 1}, seen)
+	})
+
+	t.Run("elements with different layouts", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			second func(d *abi.TupleDecoder)
+		}{
+			{
+				name:   "dynamic after static",
+				second: func(d *abi.TupleDecoder) { d.Bytes(new([]byte)) },
+			},
+			{
+				name: "more slots",
+				second: func(d *abi.TupleDecoder) {
+					d.Uint64(new(uint64)).Uint64(new(uint64))
+				},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// given
+				input, err := abi.EncodeValue(abi.MustParseType("(uint64)[]"), []any{
+					[]any{1}, []any{2},
+				})
+				require.NoError(t, err)
+				// when
+				err = abi.DecodeSliceOfTuplesFunc(input, func(i int, d *abi.TupleDecoder) error {
+					if i == 0 {
+						d.Uint64(new(uint64))
+						return nil
+					}
+					tc.second(d)
+					return nil
+				})
+				// then
+				assert.ErrorContains(t, err, "element 1, layout differs from element 0")
+			})
+		}
+	})
+
+	t.Run("not a slice type", func(t *testing.T) {
+		// given
+		input := append(abi.EncodeUint64(64), abi.EncodeUint64(0)...)
+		// when
+		err := abi.DecodeSliceOfTuplesFunc(input, func(int, *abi.TupleDecoder) error {
+			return nil
+		})
+		// then
+		assert.ErrorContains(t, err, "not a slice type")
+	})
+
+	t.Run("too many elements", func(t *testing.T) {
+		// given
+		input := append(abi.SliceHeader(), abi.EncodeUint64(2)...)
+		input = append(input, abi.EncodeUint64(1)...)
+		// when
+		err := abi.DecodeSliceOfTuplesFunc(input, func(_ int, d *abi.TupleDecoder) error {
+			var v uint64
+			d.Uint64(&v)
+			return nil
+		})
+		// then
+		assert.ErrorContains(t, err, "tail too short for 2 elements")
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		// given
+		input := append(abi.SliceHeader(), abi.EncodeUint64(2)...)
+		input = append(input, abi.EncodeUint64(1)...)
+		input = append(input, nZeros(31)...)
+		input = append(input, 1)
+		input[len(input)-32] = 1
+		// when
+		err := abi.DecodeSliceOfTuplesFunc(input, func(_ int, d *abi.TupleDecoder) error {
+			var v uint64
+			d.Uint64(&v)
+			return nil
+		})
+		// then
+		assert.ErrorContains(t, err, "decoding element 1")
+	})
+}
+
+func TestDecodeSliceOfTuplesFuncCtx(t *testing.T) {
+	// a large slice of tuples holding bytes
+	const elements = 10_000
+	values := make([]any, elements)
+	for i := range values {
+		values[i] = []any{i, []byte("some bytes")}
+	}
+	input, err := abi.EncodeValue(abi.MustParseType("(uint64,bytes)[]"), values)
+	require.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		// when
+		var last uint64
+		err := abi.DecodeSliceOfTuplesFuncCtx(
+			context.Background(),
+			input,
+			func(_ int, d *abi.TupleDecoder) error {
+				var b []byte
+				d.Uint64(&last).Bytes(&b)
+				return nil
+			},
+		)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(elements-1), last)
+	})
+
+	t.Run("cancelled while decoding", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// when
+		count := 0
+		err := abi.DecodeSliceOfTuplesFuncCtx(ctx, input, func(i int, d *abi.TupleDecoder) error {
+			count++
+			if i == 100 {
+				cancel()
+			}
+			var v uint64
+			var b []byte
+			d.Uint64(&v).Bytes(&b)
+			return nil
+		})
+		// then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, count, 200)
+	})
+}
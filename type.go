@@ -0,0 +1,345 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TypeKind identifies the kind of an ABI type.
+type TypeKind int
+
+// The kinds of ABI types.
+const (
+	UintKind TypeKind = iota + 1
+	IntKind
+	BoolKind
+	AddressKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+// Type describes an ABI type.  Types are used to drive schema based
+// decoding, where the layout of the input is only known at runtime.
+type Type struct {
+	Kind TypeKind
+	// Size is the bit width of a UintKind or IntKind, the byte width of a
+	// FixedBytesKind and the number of elements of an ArrayKind.
+	Size int
+	// Elem is the element type of a SliceKind or ArrayKind.
+	Elem *Type
+	// Components are the field types of a TupleKind.
+	Components []Type
+}
+
+// UintType returns the type uint<bits>.
+func UintType(bits int) Type {
+	return Type{Kind: UintKind, Size: bits}
+}
+
+// IntType returns the type int<bits>.
+func IntType(bits int) Type {
+	return Type{Kind: IntKind, Size: bits}
+}
+
+// BoolType returns the type bool.
+func BoolType() Type {
+	return Type{Kind: BoolKind}
+}
+
+// AddressType returns the type address.
+func AddressType() Type {
+	return Type{Kind: AddressKind}
+}
+
+// FixedBytesType returns the type bytes<n>.
+func FixedBytesType(n int) Type {
+	return Type{Kind: FixedBytesKind, Size: n}
+}
+
+// BytesType returns the type bytes.
+func BytesType() Type {
+	return Type{Kind: BytesKind}
+}
+
+// StringType returns the type string.
+func StringType() Type {
+	return Type{Kind: StringKind}
+}
+
+// SliceType returns the type elem[].
+func SliceType(elem Type) Type {
+	return Type{Kind: SliceKind, Elem: &elem}
+}
+
+// ArrayType returns the type elem[n].
+func ArrayType(elem Type, n int) Type {
+	return Type{Kind: ArrayKind, Size: n, Elem: &elem}
+}
+
+// TupleType returns the type (components[0],components[1],...).
+func TupleType(components ...Type) Type {
+	return Type{Kind: TupleKind, Components: components}
+}
+
+// String returns the canonical name of the type, as it would appear in a
+// function signature.
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return fmt.Sprintf("uint%d", t.Size)
+	case IntKind:
+		return fmt.Sprintf("int%d", t.Size)
+	case BoolKind:
+		return "bool"
+	case AddressKind:
+		return "address"
+	case FixedBytesKind:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%s[%d]", t.Elem.String(), t.Size)
+	case TupleKind:
+		names := make([]string, len(t.Components))
+		for i := range t.Components {
+			names[i] = t.Components[i].String()
+		}
+		return "(" + strings.Join(names, ",") + ")"
+	}
+	return fmt.Sprintf("unknown(%d)", t.Kind)
+}
+
+// IsDynamic reports whether values of the type are stored in the tail
+// of an encoding, with an offset in the head, rather than inline.
+func (t Type) IsDynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem != nil && t.Elem.IsDynamic()
+	case TupleKind:
+		for i := range t.Components {
+			if t.Components[i].IsDynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasNestedOffsets reports whether the encoding of a value of the type
+// holds offsets of its own, that is, whether it is a slice, array or tuple
+// with dynamic elements, whose offsets DecodeOptions.AbsoluteOffsets
+// resolves differently.  The type must be valid.
+func (t Type) hasNestedOffsets() bool {
+	switch t.Kind {
+	case SliceKind, ArrayKind:
+		return t.Elem.IsDynamic()
+	case TupleKind:
+		return t.IsDynamic()
+	}
+	return false
+}
+
+// maxHeadSize bounds the head of the encoding of a valid type, and of the
+// elements of a valid array, so that lengths computed from head sizes,
+// such as that of a head followed by its tails, cannot overflow.
+const maxHeadSize = math.MaxInt / 32
+
+// checkedHeadSize returns the number of bytes the type occupies in the
+// head of an encoding, like headSize, but errors rather than dereferencing
+// a missing element type or exceeding maxHeadSize.  An array is checked
+// even when it is dynamic, as its elements then fill a head of their own.
+func (t Type) checkedHeadSize() (int, error) {
+	switch t.Kind {
+	case SliceKind, ArrayKind:
+		if t.Elem == nil {
+			return 0, errors.New("missing element type")
+		}
+		elemSize, err := t.Elem.checkedHeadSize()
+		if err != nil {
+			return 0, err
+		}
+		if t.Kind == ArrayKind && t.Size > maxHeadSize/elemSize {
+			return 0, fmt.Errorf("array of %d elements too large", t.Size)
+		}
+		if t.IsDynamic() {
+			return 32, nil
+		}
+		return t.Size * elemSize, nil
+	case TupleKind:
+		size := 0
+		for i := range t.Components {
+			componentSize, err := t.Components[i].checkedHeadSize()
+			if err != nil {
+				return 0, err
+			}
+			if componentSize > maxHeadSize-size {
+				return 0, errors.New("tuple too large")
+			}
+			size += componentSize
+		}
+		if t.IsDynamic() {
+			return 32, nil
+		}
+		return size, nil
+	}
+	return 32, nil
+}
+
+// headSize returns the number of bytes the type occupies in the head of
+// an encoding.  Dynamic types occupy a single offset word, static types
+// are stored inline.  The type must be valid.
+func (t Type) headSize() int {
+	if t.IsDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for i := range t.Components {
+			size += t.Components[i].headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// HeadSlots returns the number of 32-byte slots that a tuple whose fields
+// are described by types occupies in the head of its encoding.  Dynamic
+// fields and static scalars occupy a single slot, while static arrays and
+// tuples occupy a slot for each of their static elements, so the count may
+// exceed the number of fields.  A valid encoding is at least 32*HeadSlots
+// bytes long.  It errors if a type is invalid or if the head is too large
+// to be counted.
+func HeadSlots(types []Type) (int, error) {
+	size := 0
+	for i := range types {
+		if err := types[i].validate(); err != nil {
+			return 0, fmt.Errorf("invalid type for element %d: %w", i, err)
+		}
+		typeSize := types[i].headSize()
+		if typeSize > maxHeadSize-size {
+			return 0, errors.New("head too large")
+		}
+		size += typeSize
+	}
+	return size / 32, nil
+}
+
+// headSlots is like HeadSlots for types that are known to be valid and to
+// fit in a head together, such as those of a validated tuple.
+func headSlots(types []Type) int {
+	size := 0
+	for i := range types {
+		size += types[i].headSize()
+	}
+	return size / 32
+}
+
+// MinEncodedLen returns the length of the shortest valid encoding of a
+// tuple whose fields are described by types.  It is the length of the
+// head plus, for each dynamic field, the shortest tail that field can have,
+// such as a single length word for empty bytes.  It errors if a type is
+// invalid or if the length is too large to be counted.
+func MinEncodedLen(types []Type) (int, error) {
+	slots, err := HeadSlots(types)
+	if err != nil {
+		return 0, err
+	}
+	return minEncodedLen(types, 32*slots)
+}
+
+// minEncodedLen adds the shortest tails of the valid types to the length of
+// their head.
+func minEncodedLen(types []Type, headSize int) (int, error) {
+	size := headSize
+	for i := range types {
+		if !types[i].IsDynamic() {
+			continue
+		}
+		tailSize, err := types[i].minTailSize()
+		if err != nil {
+			return 0, err
+		}
+		if tailSize > math.MaxInt-size {
+			return 0, errors.New("encoding too large")
+		}
+		size += tailSize
+	}
+	return size, nil
+}
+
+// minTailSize returns the length of the shortest tail of a valid dynamic
+// type.
+func (t Type) minTailSize() (int, error) {
+	switch t.Kind {
+	case ArrayKind:
+		// every element takes the same space, so count one and scale it
+		elemSize, err := minEncodedLen([]Type{*t.Elem}, t.Elem.headSize())
+		if err != nil {
+			return 0, err
+		}
+		if t.Size > math.MaxInt/elemSize {
+			return 0, errors.New("encoding too large")
+		}
+		return t.Size * elemSize, nil
+	case TupleKind:
+		return minEncodedLen(t.Components, 32*headSlots(t.Components))
+	}
+	// bytes, strings and slices hold at least their length
+	return 32, nil
+}
+
+// validate checks that the type is well formed.
+func (t Type) validate() error {
+	switch t.Kind {
+	case UintKind, IntKind:
+		if t.Size < 8 || t.Size > 256 || t.Size%8 != 0 {
+			return fmt.Errorf("invalid integer size %d", t.Size)
+		}
+	case BoolKind, AddressKind, BytesKind, StringKind:
+	case FixedBytesKind:
+		if t.Size < 1 || t.Size > 32 {
+			return fmt.Errorf("invalid fixed bytes size %d", t.Size)
+		}
+	case SliceKind, ArrayKind:
+		if t.Elem == nil {
+			return errors.New("missing element type")
+		}
+		if t.Kind == ArrayKind && t.Size < 1 {
+			return fmt.Errorf("invalid array length %d", t.Size)
+		}
+		if err := t.Elem.validate(); err != nil {
+			return fmt.Errorf("element: %w", err)
+		}
+	case TupleKind:
+		if len(t.Components) == 0 {
+			return errors.New("tuple has no components")
+		}
+		for i := range t.Components {
+			if err := t.Components[i].validate(); err != nil {
+				return fmt.Errorf("component %d: %w", i, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type kind %d", t.Kind)
+	}
+
+	// the components are valid, but together they may not fit in a head
+	_, err := t.checkedHeadSize()
+	return err
+}
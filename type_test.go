@@ -0,0 +1,234 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestType_String(t *testing.T) {
+	for _, tc := range []struct {
+		input abi.Type
+		want  string
+	}{
+		{input: abi.UintType(256), want: "uint256"},
+		{input: abi.IntType(8), want: "int8"},
+		{input: abi.BoolType(), want: "bool"},
+		{input: abi.AddressType(), want: "address"},
+		{input: abi.FixedBytesType(32), want: "bytes32"},
+		{input: abi.BytesType(), want: "bytes"},
+		{input: abi.StringType(), want: "string"},
+		{input: abi.SliceType(abi.UintType(64)), want: "uint64[]"},
+		{input: abi.ArrayType(abi.BytesType(), 3), want: "bytes[3]"},
+		{
+			input: abi.SliceType(abi.TupleType(abi.AddressType(), abi.BytesType())),
+			want:  "(address,bytes)[]",
+		},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			// when
+			got := tc.input.String()
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestType_IsDynamic(t *testing.T) {
+	for _, tc := range []struct {
+		input abi.Type
+		want  bool
+	}{
+		{input: abi.UintType(256), want: false},
+		{input: abi.FixedBytesType(32), want: false},
+		{input: abi.BytesType(), want: true},
+		{input: abi.StringType(), want: true},
+		{input: abi.SliceType(abi.UintType(64)), want: true},
+		{input: abi.ArrayType(abi.UintType(64), 3), want: false},
+		{input: abi.ArrayType(abi.BytesType(), 3), want: true},
+		{input: abi.TupleType(abi.AddressType(), abi.BoolType()), want: false},
+		{input: abi.TupleType(abi.AddressType(), abi.BytesType()), want: true},
+	} {
+		t.Run(tc.input.String(), func(t *testing.T) {
+			// when
+			got := tc.input.IsDynamic()
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHeadSlots(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input []abi.Type
+		want  int
+	}{
+		{name: "no fields", input: []abi.Type{}, want: 0},
+		{
+			name:  "scalars and dynamic fields",
+			input: []abi.Type{abi.UintType(256), abi.BytesType(), abi.SliceType(abi.UintType(64))},
+			want:  3,
+		},
+		{
+			name:  "static array",
+			input: []abi.Type{abi.ArrayType(abi.UintType(64), 3), abi.BoolType()},
+			want:  4,
+		},
+		{
+			name:  "nested static array",
+			input: []abi.Type{abi.ArrayType(abi.ArrayType(abi.AddressType(), 2), 3)},
+			want:  6,
+		},
+		{
+			name:  "static tuple with array",
+			input: []abi.Type{abi.TupleType(abi.BoolType(), abi.ArrayType(abi.UintType(8), 2))},
+			want:  3,
+		},
+		{
+			name:  "dynamic array",
+			input: []abi.Type{abi.ArrayType(abi.BytesType(), 3)},
+			want:  1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := abi.HeadSlots(tc.input)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input []abi.Type
+		want  string
+	}{
+		{
+			name:  "invalid type",
+			input: []abi.Type{abi.BoolType(), abi.UintType(7)},
+			want:  "invalid type for element 1: invalid integer size 7",
+		},
+		{
+			name:  "missing element type",
+			input: []abi.Type{{Kind: abi.ArrayKind, Size: 2}},
+			want:  "missing element type",
+		},
+		{
+			name:  "array too large",
+			input: []abi.Type{abi.ArrayType(abi.UintType(256), 1<<59)},
+			want:  "array of 576460752303423488 elements too large",
+		},
+		{
+			name: "fields too large together",
+			input: []abi.Type{
+				abi.ArrayType(abi.UintType(256), 1<<52),
+				abi.ArrayType(abi.UintType(256), 1<<52),
+			},
+			want: "head too large",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := abi.HeadSlots(tc.input)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestMinEncodedLen(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input []abi.Type
+		want  int
+	}{
+		{name: "no fields", input: []abi.Type{}, want: 0},
+		{
+			name:  "static fields",
+			input: []abi.Type{abi.UintType(256), abi.ArrayType(abi.BoolType(), 2)},
+			want:  3 * 32,
+		},
+		{
+			name:  "static and dynamic fields",
+			input: []abi.Type{abi.UintType(256), abi.BytesType(), abi.StringType()},
+			want:  3*32 + 2*32,
+		},
+		{
+			name:  "slice",
+			input: []abi.Type{abi.SliceType(abi.BytesType())},
+			want:  32 + 32,
+		},
+		{
+			name:  "dynamic tuple",
+			input: []abi.Type{abi.TupleType(abi.AddressType(), abi.BytesType())},
+			want:  32 + 2*32 + 32,
+		},
+		{
+			name:  "dynamic array",
+			input: []abi.Type{abi.ArrayType(abi.BytesType(), 2)},
+			want:  32 + 2*32 + 2*32,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := abi.MinEncodedLen(tc.input)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input []abi.Type
+		want  string
+	}{
+		{
+			name:  "missing element type",
+			input: []abi.Type{{Kind: abi.ArrayKind, Size: 2}},
+			want:  "missing element type",
+		},
+		{
+			name:  "array head too large",
+			input: []abi.Type{abi.ArrayType(abi.UintType(256), 1<<59)},
+			want:  "array of 576460752303423488 elements too large",
+		},
+		{
+			name: "array tail too large",
+			input: []abi.Type{
+				abi.ArrayType(abi.ArrayType(abi.BytesType(), 1<<20), 1<<38),
+			},
+			want: "encoding too large",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := abi.MinEncodedLen(tc.input)
+			// then
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+
+	t.Run("matches the encoding of empty values", func(t *testing.T) {
+		// given
+		types := []abi.Type{
+			abi.UintType(8),
+			abi.TupleType(abi.AddressType(), abi.BytesType()),
+			abi.SliceType(abi.UintType(256)),
+		}
+		encoded, err := abi.Encode(types, []any{
+			0, []any{abi.ZeroAddress, []byte{}}, []any{},
+		})
+		require.NoError(t, err)
+		// when
+		got, err := abi.MinEncodedLen(types)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, len(encoded), got)
+	})
+}
@@ -0,0 +1,34 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// ReadWord returns the index-th 32-byte word of data.  The word aliases
+// data, but its capacity is limited to the word, so appending to it does
+// not overwrite the words that follow.  It is a building block for custom
+// codecs, which would otherwise slice words out of data by hand.
+func ReadWord(data []byte, index int) ([]byte, error) {
+	// index is compared against the number of words rather than
+	// multiplied, which could overflow
+	if index < 0 || index >= len(data)/32 {
+		return nil, fmt.Errorf("word %d out of range of %d bytes", index, len(data))
+	}
+
+	start, end := 32*index, 32*(index+1)
+	return data[start:end:end], nil
+}
+
+// WriteWord copies word, which must contain 32 bytes, into the index-th
+// 32-byte word of dst.  It is the counterpart of ReadWord.
+func WriteWord(dst []byte, index int, word []byte) error {
+	switch {
+	case len(word) != 32:
+		return fmt.Errorf("word of %d bytes must contain 32 bytes", len(word))
+	case index < 0 || index >= len(dst)/32:
+		return fmt.Errorf("word %d out of range of %d bytes", index, len(dst))
+	}
+
+	copy(dst[32*index:], word)
+	return nil
+}
@@ -0,0 +1,101 @@
+package abi_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blocky/abi"
+)
+
+func TestReadWord(t *testing.T) {
+	data := append(abi.EncodeUint64(1), abi.EncodeUint64(2)...)
+
+	t.Run("happy path", func(t *testing.T) {
+		for i, want := range [][]byte{abi.EncodeUint64(1), abi.EncodeUint64(2)} {
+			// when
+			got, err := abi.ReadWord(data, i)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("appending does not overwrite the next word", func(t *testing.T) {
+		// given
+		input := append([]byte{}, data...)
+		word, err := abi.ReadWord(input, 0)
+		require.NoError(t, err)
+		// when
+		_ = append(word, 0xff)
+		// then
+		assert.Equal(t, data, input)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, tc := range []struct {
+			name  string
+			data  []byte
+			index int
+		}{
+			{name: "negative", data: data, index: -1},
+			{name: "past the end", data: data, index: 2},
+			{name: "partial word", data: data[:63], index: 1},
+			{name: "overflowing index", data: data, index: math.MaxInt/32 + 1},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				_, err := abi.ReadWord(tc.data, tc.index)
+				// then
+				assert.ErrorContains(t, err, "out of range")
+			})
+		}
+	})
+}
+
+func TestWriteWord(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		dst := make([]byte, 64)
+		// when
+		err := abi.WriteWord(dst, 1, abi.EncodeUint64(7))
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, append(nZeros(32), abi.EncodeUint64(7)...), dst)
+	})
+
+	t.Run("round trip with ReadWord", func(t *testing.T) {
+		// given
+		dst := make([]byte, 96)
+		require.NoError(t, abi.WriteWord(dst, 2, abi.EncodeUint64(42)))
+		// when
+		word, err := abi.ReadWord(dst, 2)
+		require.NoError(t, err)
+		got, err := abi.DecodeUint64(word)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			index   int
+			word    []byte
+			wantErr string
+		}{
+			{name: "short word", index: 0, word: nZeros(31), wantErr: "word of 31 bytes must contain 32 bytes"},
+			{name: "negative", index: -1, word: nZeros(32), wantErr: "word -1 out of range of 64 bytes"},
+			{name: "past the end", index: 2, word: nZeros(32), wantErr: "word 2 out of range of 64 bytes"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// when
+				err := abi.WriteWord(make([]byte, 64), tc.index, tc.word)
+				// then
+				assert.EqualError(t, err, tc.wantErr)
+			})
+		}
+	})
+}